@@ -8,13 +8,20 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Profile timezones are validated against the IANA database, which the
+	// runtime image may lack.
+	_ "time/tzdata"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/config"
 	delivery "github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/handlers/health"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/database"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/metrics"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/worker/absence"
 	_ "github.com/derletzte256/avito-assignment-2025-autumn/migrations"
 	"go.uber.org/zap"
 )
@@ -30,6 +37,11 @@ func main() {
 
 	l := logger.Get()
 
+	shutdownTracing, err := tracing.Init(ctx, cfg.Tracing)
+	if err != nil {
+		l.Fatal("init tracing", zap.Error(err))
+	}
+
 	if err = database.RunMigrations(ctx, cfg.Database); err != nil {
 		l.Fatal("run migrations", zap.Error(err))
 	}
@@ -40,9 +52,39 @@ func main() {
 	}
 	defer pool.Close()
 
-	trManager := manager.Must(trmpgx.NewDefaultFactory(pool))
+	m := metrics.New()
+	m.MustRegister(metrics.NewPoolCollector(pool))
+
+	trManager := metrics.NewTransactor(manager.Must(trmpgx.NewDefaultFactory(pool)), m)
+
+	migrationsCheck, err := database.NewMigrationsCheck(pool)
+	if err != nil {
+		l.Fatal("init migrations check", zap.Error(err))
+	}
+
+	healthDelivery := health.NewDelivery(
+		health.Check{Name: "database", Fn: pool.Ping},
+		health.Check{Name: "migrations", Fn: migrationsCheck},
+	)
 
-	router := delivery.NewRouter(pool, trManager)
+	useCases := delivery.NewUseCases(pool, trManager, m, cfg.Review)
+
+	// The worker gets its own context so it is stopped only after traffic is
+	// drained, and is waited for before the server and tracing go down.
+	workerCtx, stopWorker := context.WithCancel(logger.WithCtx(context.Background(), l))
+	defer stopWorker()
+	workerDone := make(chan struct{})
+	if cfg.Review.AbsenceReassignDays > 0 {
+		worker := absence.NewWorker(useCases.User, cfg.Review.AbsenceReassignDays, cfg.Review.AbsenceCheckInterval)
+		go func() {
+			defer close(workerDone)
+			worker.Run(workerCtx)
+		}()
+	} else {
+		close(workerDone)
+	}
+
+	router := delivery.NewRouter(useCases, m, cfg.Tracing.ServiceName, healthDelivery)
 
 	srv := delivery.NewServer(cfg.HTTP, router, l)
 
@@ -54,10 +96,25 @@ func main() {
 
 	<-ctx.Done()
 
+	healthDelivery.SetShuttingDown()
+	l.Info("shutdown requested, draining traffic", zap.Duration("drain_delay", cfg.HTTP.ShutdownDrainDelay))
+	time.Sleep(cfg.HTTP.ShutdownDrainDelay)
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
 
+	stopWorker()
+	select {
+	case <-workerDone:
+	case <-shutdownCtx.Done():
+		l.Error("absence worker did not stop in time")
+	}
+
 	if err = srv.Shutdown(shutdownCtx); err != nil {
 		l.Fatal("http server shutdown", zap.Error(err))
 	}
+
+	if err = shutdownTracing(shutdownCtx); err != nil {
+		l.Error("tracing shutdown", zap.Error(err))
+	}
 }
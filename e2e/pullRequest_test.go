@@ -15,12 +15,16 @@ type PullRequest struct {
 	Status            string     `json:"status"`
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	MergedAt          *time.Time `json:"merged_at"`
+	AwaitingReviewers int        `json:"awaiting_reviewers"`
+	// CrossTeamReviewers lists reviewers borrowed from related teams.
+	CrossTeamReviewers []string `json:"cross_team_reviewers"`
 }
 
 type CreatePullRequestRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	ReviewerIDs     []string `json:"reviewer_ids,omitempty"`
 }
 
 type MergePullRequestRequest struct {
@@ -35,6 +39,7 @@ type ReassignPullRequestRequest struct {
 type ReassignPullRequestResponse struct {
 	PR         PullRequest `json:"pr"`
 	ReplacedBy string      `json:"replaced_by"`
+	Escalated  bool        `json:"escalated"`
 }
 
 type APIError struct {
@@ -730,3 +735,326 @@ func TestReassignPullRequest_NoCandidate(t *testing.T) {
 
 	req.Equal("NO_CANDIDATE", errResp.Error.Code)
 }
+
+func TestReassignPullRequest_EscalatesToLead(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	type member struct {
+		TeamMember
+		Role string `json:"role,omitempty"`
+	}
+	team := struct {
+		TeamName       string   `json:"team_name"`
+		Members        []member `json:"members"`
+		EscalateToLead bool     `json:"escalate_to_lead"`
+	}{
+		TeamName: "small-team",
+		Members: []member{
+			{TeamMember: TeamMember{UserID: "u-author", Username: "Author", IsActive: true}},
+			{TeamMember: TeamMember{UserID: "u-reviewer", Username: "Reviewer", IsActive: true}},
+			{TeamMember: TeamMember{UserID: "u-lead", Username: "Lead", IsActive: false}, Role: "lead"},
+		},
+		EscalateToLead: true,
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var createdPR PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr1", PullRequestName: "Escalation", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&createdPR)
+
+	req.Equal([]string{"u-reviewer"}, createdPR.AssignedReviewers)
+
+	var reassignResp ReassignPullRequestResponse
+	_ = e.POST("/pullRequest/reassign").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ReassignPullRequestRequest{PullRequestID: "pr1", OldUserID: "u-reviewer"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reassignResp)
+
+	req.Equal("u-lead", reassignResp.ReplacedBy)
+	req.True(reassignResp.Escalated)
+	req.Equal([]string{"u-lead"}, reassignResp.PR.AssignedReviewers)
+}
+
+func TestCreatePullRequest_QueuedWhenTeamAtCap(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "capped",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var settings TeamSettings
+	_ = e.GET("/team/settings").
+		WithQuery("team_name", "capped").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&settings)
+
+	settings.MaxOpenReviews = 1
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusOK)
+
+	var first PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "First", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&first)
+
+	req.ElementsMatch([]string{"u2", "u3"}, first.AssignedReviewers)
+	req.Zero(first.AwaitingReviewers)
+
+	var second PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-2", PullRequestName: "Second", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&second)
+
+	req.Empty(second.AssignedReviewers)
+	req.Equal(2, second.AwaitingReviewers)
+
+	var merged PullRequest
+	_ = e.POST("/pullRequest/merge").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MergePullRequestRequest{PullRequestID: "pr-2"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&merged)
+
+	req.Zero(merged.AwaitingReviewers)
+}
+
+type BacklogEntry struct {
+	PullRequestID     string   `json:"pull_request_id"`
+	AuthorID          string   `json:"author_id"`
+	TeamName          string   `json:"team_name"`
+	AssignedReviewers []string `json:"assigned_reviewers"`
+	MissingReviewers  int      `json:"missing_reviewers"`
+}
+
+type BacklogResponse struct {
+	PullRequests []BacklogEntry `json:"pull_requests"`
+}
+
+func TestPullRequestBacklog(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "small",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var created PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&created)
+
+	req.Equal([]string{"u2"}, created.AssignedReviewers)
+	req.Equal(1, created.AwaitingReviewers)
+
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "small", UserIDs: []string{"u2"}}).
+		Expect().
+		Status(http.StatusOK)
+
+	var backlog BacklogResponse
+	_ = e.GET("/pullRequest/backlog").
+		WithQuery("team_name", "small").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backlog)
+
+	req.Equal([]BacklogEntry{{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		TeamName:          "small",
+		AssignedReviewers: []string{"u2"},
+		MissingReviewers:  2,
+	}}, backlog.PullRequests)
+
+	// u3 replaces the deactivated reviewer, u4 joins inactive and only takes
+	// the remaining review once activated.
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(AddTeamMembersRequest{TeamName: "small", Members: []TeamMember{
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: false},
+		}}).
+		Expect().
+		Status(http.StatusOK)
+
+	_ = e.GET("/pullRequest/backlog").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backlog)
+
+	req.Len(backlog.PullRequests, 1)
+	req.Equal([]string{"u3"}, backlog.PullRequests[0].AssignedReviewers)
+	req.Equal(1, backlog.PullRequests[0].MissingReviewers)
+
+	_ = e.POST("/users/setIsActive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetUserActiveRequest{UserID: "u4", IsActive: true}).
+		Expect().
+		Status(http.StatusOK)
+
+	_ = e.GET("/pullRequest/backlog").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backlog)
+
+	req.Empty(backlog.PullRequests)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u4").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+
+	req.Len(reviews.PullRequests, 1)
+
+	_ = e.GET("/pullRequest/backlog").
+		WithQuery("team_name", "missing").
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func TestPullRequestBacklog_ReactivatedReviewer(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "small",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "small", UserIDs: []string{"u2"}}).
+		Expect().
+		Status(http.StatusOK)
+
+	// Once u2 is back, the slot queued to replace them is no longer missing.
+	_ = e.POST("/users/setIsActive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetUserActiveRequest{UserID: "u2", IsActive: true}).
+		Expect().
+		Status(http.StatusOK)
+
+	var backlog BacklogResponse
+	_ = e.GET("/pullRequest/backlog").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backlog)
+
+	req.Len(backlog.PullRequests, 1)
+	req.Equal([]string{"u2"}, backlog.PullRequests[0].AssignedReviewers)
+	req.Equal(1, backlog.PullRequests[0].MissingReviewers)
+
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(AddTeamMembersRequest{TeamName: "small", Members: []TeamMember{
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		}}).
+		Expect().
+		Status(http.StatusOK)
+
+	_ = e.GET("/pullRequest/backlog").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backlog)
+
+	req.Empty(backlog.PullRequests)
+
+	for _, userID := range []string{"u2", "u3"} {
+		var reviews UserReviewListResponse
+		_ = e.GET("/users/getReview").
+			WithQuery("user_id", userID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Decode(&reviews)
+
+		req.Len(reviews.PullRequests, 1)
+	}
+}
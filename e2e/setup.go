@@ -71,6 +71,13 @@ func truncateTables(ctx context.Context, conn *pgx.Conn) error {
 		TRUNCATE TABLE
 			reviewer,
 			pull_request,
+			team_membership,
+			team_settings,
+			user_unavailability,
+			review_queue,
+			review_queue_replacement,
+			review_audit_event,
+			deactivation_plan,
 			"user",
 			team
 		RESTART IDENTITY CASCADE;
@@ -1,8 +1,10 @@
 package e2e
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"sort"
 
@@ -140,3 +142,687 @@ func TestGetNonExistentTeam(t *testing.T) {
 		Expect().
 		Status(http.StatusNotFound)
 }
+
+type AddTeamMembersRequest struct {
+	TeamName  string       `json:"team_name"`
+	Members   []TeamMember `json:"members"`
+	Secondary bool         `json:"secondary,omitempty"`
+}
+
+type RemoveTeamMembersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+func TestAddAndRemoveTeamMembers(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-leaving", Username: "Leaving", IsActive: true},
+		},
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	createPRReq := CreatePullRequestRequest{
+		PullRequestID:   "pr1",
+		PullRequestName: "PR for member removal",
+		AuthorID:        "u-author",
+	}
+
+	var createdPR PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(createPRReq).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&createdPR)
+
+	req.Equal([]string{"u-leaving"}, createdPR.AssignedReviewers)
+	req.Equal(1, createdPR.AwaitingReviewers)
+
+	// The first newcomer takes the second review of pr1 from the backlog.
+	newcomers := []TeamMember{
+		{UserID: "u-new-1", Username: "Newcomer1", IsActive: true},
+		{UserID: "u-new-2", Username: "Newcomer2", IsActive: true},
+	}
+
+	var addResp CreateTeamResponse
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(AddTeamMembersRequest{TeamName: team.TeamName, Members: newcomers}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&addResp)
+
+	req.Equal(
+		NormalizeTeam(Team{TeamName: team.TeamName, Members: append(team.Members, newcomers...)}),
+		NormalizeTeam(addResp.Team),
+	)
+
+	var backfilled UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-new-1").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&backfilled)
+
+	req.Len(backfilled.PullRequests, 1)
+	req.Equal("pr1", backfilled.PullRequests[0].PullRequestID)
+
+	var removeResp MassDeactivateUsersResponse
+	_ = e.POST("/team/removeMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RemoveTeamMembersRequest{TeamName: team.TeamName, UserIDs: []string{"u-leaving"}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&removeResp)
+
+	req.Equal(team.TeamName, removeResp.TeamName)
+	req.Len(removeResp.ReviewReassignments, 1)
+	req.Empty(removeResp.UnreassignedReviews)
+	req.Equal("u-leaving", removeResp.ReviewReassignments[0].OldReviewerID)
+
+	var gotTeam Team
+	_ = e.GET("/team/get").
+		WithQuery("team_name", team.TeamName).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&gotTeam)
+
+	for _, member := range gotTeam.Members {
+		req.NotEqual("u-leaving", member.UserID, "removed user should not be listed in the team")
+	}
+
+	var errResp ErrorResponse
+	_ = e.POST("/team/removeMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RemoveTeamMembersRequest{TeamName: team.TeamName, UserIDs: []string{"u-leaving"}}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("NOT_TEAM_MEMBER", errResp.Error.Code)
+}
+
+func TestSecondaryTeamMembership(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	product := Team{
+		TeamName: "product",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-product", Username: "Product", IsActive: true},
+		},
+	}
+	guild := Team{
+		TeamName: "guild",
+		Members: []TeamMember{
+			{UserID: "u-guild", Username: "Guild", IsActive: true},
+		},
+	}
+
+	for _, team := range []Team{product, guild} {
+		_ = e.POST("/team/add").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(team).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	var addResp CreateTeamResponse
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(AddTeamMembersRequest{TeamName: product.TeamName, Members: guild.Members, Secondary: true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&addResp)
+
+	req.Equal(
+		NormalizeTeam(Team{TeamName: product.TeamName, Members: append(product.Members, guild.Members...)}),
+		NormalizeTeam(addResp.Team),
+	)
+
+	var gotGuild Team
+	_ = e.GET("/team/get").
+		WithQuery("team_name", guild.TeamName).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&gotGuild)
+
+	req.Equal(NormalizeTeam(guild), NormalizeTeam(gotGuild), "primary team must keep the user")
+
+	var createdPR PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr1", PullRequestName: "Guild review", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&createdPR)
+
+	req.ElementsMatch([]string{"u-product", "u-guild"}, createdPR.AssignedReviewers)
+
+	// u-writer belongs to guild and is a secondary member of product, so
+	// reviews on their PRs count as product reviews too.
+	writer := []TeamMember{{UserID: "u-writer", Username: "Writer", IsActive: true}}
+	for _, addReq := range []AddTeamMembersRequest{
+		{TeamName: guild.TeamName, Members: writer},
+		{TeamName: product.TeamName, Members: writer, Secondary: true},
+	} {
+		_ = e.POST("/team/addMembers").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(addReq).
+			Expect().
+			Status(http.StatusOK)
+	}
+
+	var writerPR PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr2", PullRequestName: "Writer PR", AuthorID: "u-writer"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&writerPR)
+
+	req.Equal([]string{"u-guild"}, writerPR.AssignedReviewers)
+
+	var deactivateResp MassDeactivateUsersResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: product.TeamName, UserIDs: []string{"u-guild"}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&deactivateResp)
+
+	req.Equal(product.TeamName, deactivateResp.TeamName)
+	req.Empty(deactivateResp.UnreassignedReviews)
+
+	reassigned := make([]string, 0, len(deactivateResp.ReviewReassignments))
+	for _, reassignment := range deactivateResp.ReviewReassignments {
+		reassigned = append(reassigned, reassignment.PullRequestID)
+	}
+	req.ElementsMatch([]string{"pr1", "pr2"}, reassigned)
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name"`
+	NewTeamName string `json:"new_team_name"`
+}
+
+func TestRenameTeam(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	for _, team := range []Team{
+		{TeamName: "team-1", Members: []TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}},
+		{TeamName: "team-2", Members: []TeamMember{{UserID: "u2", Username: "Bob", IsActive: true}}},
+	} {
+		_ = e.POST("/team/add").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(team).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	var renameResp CreateTeamResponse
+	_ = e.POST("/team/rename").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RenameTeamRequest{TeamName: "team-1", NewTeamName: "platform"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&renameResp)
+
+	expected := Team{TeamName: "platform", Members: []TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}}}
+	req.Equal(expected, NormalizeTeam(renameResp.Team))
+
+	var gotTeam Team
+	_ = e.GET("/team/get").
+		WithQuery("team_name", "platform").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&gotTeam)
+
+	req.Equal(expected, NormalizeTeam(gotTeam))
+
+	_ = e.GET("/team/get").
+		WithQuery("team_name", "team-1").
+		Expect().
+		Status(http.StatusNotFound)
+
+	var errResp ErrorResponse
+	_ = e.POST("/team/rename").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RenameTeamRequest{TeamName: "platform", NewTeamName: "team-2"}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("TEAM_EXISTS", errResp.Error.Code)
+}
+
+type ArchiveTeamRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+type ArchiveTeamResponse struct {
+	TeamName         string        `json:"team_name"`
+	ArchivedAt       time.Time     `json:"archived_at"`
+	OpenPullRequests []PullRequest `json:"open_pull_requests"`
+}
+
+func TestArchiveTeam(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-reviewer", Username: "Reviewer", IsActive: true},
+		},
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	for _, id := range []string{"pr1", "pr2"} {
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{PullRequestID: id, PullRequestName: "PR " + id, AuthorID: "u-author"}).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	_ = e.POST("/pullRequest/merge").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MergePullRequestRequest{PullRequestID: "pr2"}).
+		Expect().
+		Status(http.StatusOK)
+
+	var archiveResp ArchiveTeamResponse
+	_ = e.POST("/team/archive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ArchiveTeamRequest{TeamName: team.TeamName}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&archiveResp)
+
+	req.Equal(team.TeamName, archiveResp.TeamName)
+	req.False(archiveResp.ArchivedAt.IsZero())
+	req.Len(archiveResp.OpenPullRequests, 1)
+	req.Equal("pr1", archiveResp.OpenPullRequests[0].PullRequestID)
+	req.Equal([]string{"u-reviewer"}, archiveResp.OpenPullRequests[0].AssignedReviewers)
+
+	var again ArchiveTeamResponse
+	_ = e.POST("/team/archive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ArchiveTeamRequest{TeamName: team.TeamName}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&again)
+
+	req.True(archiveResp.ArchivedAt.Equal(again.ArchivedAt), "repeated archiving should keep archived_at")
+
+	var errResp ErrorResponse
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr3", PullRequestName: "PR pr3", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("TEAM_ARCHIVED", errResp.Error.Code)
+
+	_ = e.POST("/team/archive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ArchiveTeamRequest{TeamName: "no-such-team"}).
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+type HierarchyTeam struct {
+	TeamName       string       `json:"team_name"`
+	ParentTeamName string       `json:"parent_team_name,omitempty"`
+	Members        []TeamMember `json:"members"`
+}
+
+type SetTeamParentRequest struct {
+	TeamName       string `json:"team_name"`
+	ParentTeamName string `json:"parent_team_name"`
+}
+
+func TestTeamHierarchy(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	teams := []HierarchyTeam{
+		{TeamName: "engineering", Members: []TeamMember{{UserID: "u-lead", Username: "Lead", IsActive: true}}},
+		{TeamName: "backend", ParentTeamName: "engineering", Members: []TeamMember{{UserID: "u-author", Username: "Author", IsActive: true}}},
+		{TeamName: "frontend", ParentTeamName: "engineering", Members: []TeamMember{{UserID: "u-front", Username: "Front", IsActive: true}}},
+	}
+
+	for _, team := range teams {
+		var createResp struct {
+			Team HierarchyTeam `json:"team"`
+		}
+		_ = e.POST("/team/add").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(team).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().Decode(&createResp)
+
+		req.Equal(team.ParentTeamName, createResp.Team.ParentTeamName)
+	}
+
+	// backend has nobody but the author, so reviewers come from the sibling
+	// team first and then from the parent.
+	var pr PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr1", PullRequestName: "Cross-team PR", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&pr)
+
+	req.ElementsMatch([]string{"u-front", "u-lead"}, pr.AssignedReviewers)
+	req.ElementsMatch([]string{"u-front", "u-lead"}, pr.CrossTeamReviewers)
+
+	var errResp ErrorResponse
+	_ = e.POST("/team/setParent").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetTeamParentRequest{TeamName: "engineering", ParentTeamName: "backend"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("INVALID_INPUT", errResp.Error.Code)
+
+	var detached struct {
+		Team HierarchyTeam `json:"team"`
+	}
+	_ = e.POST("/team/setParent").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetTeamParentRequest{TeamName: "backend"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&detached)
+
+	req.Equal("backend", detached.Team.TeamName)
+	req.Empty(detached.Team.ParentTeamName)
+
+	// Without a parent backend has no related teams to borrow from.
+	var lonely PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr2", PullRequestName: "Lonely PR", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&lonely)
+
+	req.Empty(lonely.AssignedReviewers)
+	req.Empty(lonely.CrossTeamReviewers)
+
+	_ = e.POST("/team/setParent").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetTeamParentRequest{TeamName: "backend", ParentTeamName: "no-such-team"}).
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+type TeamSettings struct {
+	TeamName               string `json:"team_name"`
+	ReviewerCount          int    `json:"reviewer_count"`
+	SelectionStrategy      string `json:"selection_strategy"`
+	ReviewSLAHours         int    `json:"review_sla_hours"`
+	ApprovalQuorum         int    `json:"approval_quorum"`
+	AuthorChoosesReviewers bool   `json:"author_chooses_reviewers"`
+	CrossTeamFallback      bool   `json:"cross_team_fallback"`
+	MaxOpenReviews         int    `json:"max_open_reviews"`
+	Version                int    `json:"version"`
+}
+
+func TestTeamSettings(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "settings-team",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-1", Username: "One", IsActive: true},
+			{UserID: "u-2", Username: "Two", IsActive: true},
+		},
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	getResp := e.GET("/team/settings").
+		WithQuery("team_name", team.TeamName).
+		Expect().
+		Status(http.StatusOK)
+	getResp.Header("ETag").IsEqual(`"1"`)
+
+	var settings TeamSettings
+	getResp.JSON().Object().Decode(&settings)
+	req.Equal(2, settings.ReviewerCount)
+	req.Equal("random", settings.SelectionStrategy)
+
+	settings.ReviewerCount = 1
+	settings.ApprovalQuorum = 1
+	settings.AuthorChoosesReviewers = true
+
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusPreconditionRequired)
+
+	putResp := e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusOK)
+	putResp.Header("ETag").IsEqual(`"2"`)
+
+	var errResp ErrorResponse
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusPreconditionFailed).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("PRECONDITION_FAILED", errResp.Error.Code)
+
+	var createdPR PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{
+			PullRequestID:   "pr1",
+			PullRequestName: "Chosen reviewer",
+			AuthorID:        "u-author",
+			ReviewerIDs:     []string{"u-2"},
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&createdPR)
+
+	req.Equal([]string{"u-2"}, createdPR.AssignedReviewers)
+}
+
+type RebalanceTeamRequest struct {
+	TeamName  string  `json:"team_name"`
+	Tolerance float64 `json:"tolerance,omitempty"`
+	DryRun    bool    `json:"dry_run,omitempty"`
+}
+
+type RebalanceTeamResponse struct {
+	TeamName            string               `json:"team_name"`
+	DryRun              bool                 `json:"dry_run"`
+	MeanOpenReviews     float64              `json:"mean_open_reviews"`
+	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
+	OpenReviews         map[string]int       `json:"open_reviews"`
+}
+
+func TestRebalanceTeam(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-busy-1", Username: "Busy1", IsActive: true},
+			{UserID: "u-busy-2", Username: "Busy2", IsActive: true},
+			{UserID: "u-new", Username: "New", IsActive: false},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	for i := range 3 {
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{
+				PullRequestID:   fmt.Sprintf("pr-%d", i),
+				PullRequestName: "Feature",
+				AuthorID:        "u-author",
+			}).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	_ = e.POST("/users/setIsActive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetUserActiveRequest{UserID: "u-new", IsActive: true}).
+		Expect().
+		Status(http.StatusOK)
+
+	var preview RebalanceTeamResponse
+	_ = e.POST("/team/rebalance").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RebalanceTeamRequest{TeamName: "team-1", DryRun: true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&preview)
+
+	// The author cannot review their own PRs, so only u-new takes reviews over.
+	req.True(preview.DryRun)
+	req.InDelta(1.5, preview.MeanOpenReviews, 1e-9)
+	req.Len(preview.ReviewReassignments, 2)
+	oldReviewers := make([]string, 0, 2)
+	for _, moved := range preview.ReviewReassignments {
+		req.Equal("u-new", moved.NewReviewerID)
+		oldReviewers = append(oldReviewers, moved.OldReviewerID)
+	}
+	req.ElementsMatch([]string{"u-busy-1", "u-busy-2"}, oldReviewers)
+	req.Equal(map[string]int{"u-author": 0, "u-busy-1": 2, "u-busy-2": 2, "u-new": 2}, preview.OpenReviews)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-new").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+	req.Empty(reviews.PullRequests)
+
+	var applied RebalanceTeamResponse
+	_ = e.POST("/team/rebalance").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RebalanceTeamRequest{TeamName: "team-1"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&applied)
+
+	req.False(applied.DryRun)
+	req.Len(applied.ReviewReassignments, 2)
+
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-new").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+	req.Len(reviews.PullRequests, 2)
+
+	var again RebalanceTeamResponse
+	_ = e.POST("/team/rebalance").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RebalanceTeamRequest{TeamName: "team-1"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&again)
+	req.Empty(again.ReviewReassignments)
+
+	var errResp ErrorResponse
+	_ = e.POST("/team/rebalance").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(RebalanceTeamRequest{TeamName: "unknown-team"}).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().Decode(&errResp)
+	req.Equal("NOT_FOUND", errResp.Error.Code)
+}
@@ -1,26 +1,38 @@
 package e2e
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 type User struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	Email          string `json:"email,omitempty"`
+	ChatHandle     string `json:"chat_handle,omitempty"`
+	Timezone       string `json:"timezone,omitempty"`
+	Seniority      string `json:"seniority,omitempty"`
+	WorkHoursStart string `json:"work_hours_start,omitempty"`
+	WorkHoursEnd   string `json:"work_hours_end,omitempty"`
 }
 
 type SetUserActiveRequest struct {
-	UserID   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserID              string `json:"user_id"`
+	IsActive            bool   `json:"is_active"`
+	TargetOpenReviews   int    `json:"target_open_reviews,omitempty"`
+	ReassignOpenReviews bool   `json:"reassign_open_reviews,omitempty"`
 }
 
 type SetUserActiveResponse struct {
-	User User `json:"user"`
+	User                User                 `json:"user"`
+	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []UnreassignedReview `json:"unreassigned_reviews"`
 }
 
 type PullRequestShort struct {
@@ -47,8 +59,10 @@ type StatsByUsersResponse struct {
 }
 
 type MassDeactivateUsersRequest struct {
-	TeamName string   `json:"team_name"`
-	UserIDs  []string `json:"user_ids"`
+	TeamName  string   `json:"team_name,omitempty"`
+	UserIDs   []string `json:"user_ids"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+	PlanToken string   `json:"plan_token,omitempty"`
 }
 
 type ReviewReassignment struct {
@@ -66,6 +80,47 @@ type MassDeactivateUsersResponse struct {
 	TeamName            string               `json:"team_name"`
 	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
 	UnreassignedReviews []UnreassignedReview `json:"unreassigned_reviews"`
+	Teams               []TeamDeactivation   `json:"teams"`
+	DryRun              bool                 `json:"dry_run"`
+	PlanToken           string               `json:"plan_token"`
+}
+
+type TeamDeactivation struct {
+	TeamName            string               `json:"team_name"`
+	UserIDs             []string             `json:"user_ids"`
+	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []UnreassignedReview `json:"unreassigned_reviews"`
+}
+
+type ChangeUserTeamRequest struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+type ChangeUserTeamResponse struct {
+	User                User                 `json:"user"`
+	OldTeamName         string               `json:"old_team_name"`
+	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []UnreassignedReview `json:"unreassigned_reviews"`
+}
+
+type HandoverReviewsRequest struct {
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+	Scope      string `json:"scope,omitempty"`
+}
+
+type SkippedReview struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+type HandoverReviewsResponse struct {
+	FromUserID string               `json:"from_user_id"`
+	ToUserID   string               `json:"to_user_id"`
+	Scope      string               `json:"scope"`
+	Moved      []ReviewReassignment `json:"moved"`
+	Skipped    []SkippedReview      `json:"skipped"`
 }
 
 func TestSetUserIsActive(t *testing.T) {
@@ -433,3 +488,1032 @@ func TestMassDeactivateUsers_WithReassignment(t *testing.T) {
 	}
 	req.True(foundPR, "new reviewer should have the PR in review list")
 }
+
+type SearchUsersResponse struct {
+	Users   []User `json:"users"`
+	Limit   int    `json:"limit"`
+	Offset  int    `json:"offset"`
+	HasMore bool   `json:"has_more"`
+}
+
+func TestUserCRUD(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(Team{TeamName: "backend", Members: []TeamMember{}}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var created SetUserActiveResponse
+	_ = e.POST("/users/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "hr-1", "username": "Alice", "team_name": "backend"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&created)
+
+	req.Equal(User{UserID: "hr-1", Username: "Alice", TeamName: "backend", IsActive: true}, created.User)
+
+	_ = e.POST("/users/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "hr-2", "username": "Alina"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "hr-1", "username": "Again"}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("USER_EXISTS", errResp.Error.Code)
+
+	var updated SetUserActiveResponse
+	_ = e.POST("/users/update").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "hr-1", "username": "Alice B."}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&updated)
+
+	req.Equal("Alice B.", updated.User.Username)
+
+	var got SetUserActiveResponse
+	_ = e.GET("/users/get").
+		WithQuery("user_id", "hr-1").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&got)
+
+	req.Equal(updated.User, got.User)
+
+	var page SearchUsersResponse
+	_ = e.GET("/users/search").
+		WithQuery("query", "ali").
+		WithQuery("limit", 1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&page)
+
+	req.Len(page.Users, 1)
+	req.Equal("hr-1", page.Users[0].UserID)
+	req.True(page.HasMore)
+
+	_ = e.GET("/users/search").
+		WithQuery("query", "hr-").
+		WithQuery("limit", 1).
+		WithQuery("offset", 1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&page)
+
+	req.Len(page.Users, 1)
+	req.Equal("hr-2", page.Users[0].UserID)
+	req.False(page.HasMore)
+}
+
+func TestUserProfile(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{{
+			"user_id":     "u1",
+			"username":    "Alice",
+			"is_active":   true,
+			"email":       "alice@example.com",
+			"chat_handle": "@alice",
+			"timezone":    "Europe/Moscow",
+			"seniority":   "senior",
+		}},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var got SetUserActiveResponse
+	_ = e.GET("/users/get").
+		WithQuery("user_id", "u1").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&got)
+
+	req.Equal(User{
+		UserID:     "u1",
+		Username:   "Alice",
+		TeamName:   "backend",
+		IsActive:   true,
+		Email:      "alice@example.com",
+		ChatHandle: "@alice",
+		Timezone:   "Europe/Moscow",
+		Seniority:  "senior",
+	}, got.User)
+
+	// Re-adding the member without profile fields keeps them.
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{
+			"team_name": "backend",
+			"members":   []TeamMember{{UserID: "u1", Username: "Alice", IsActive: true}},
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	_ = e.POST("/users/update").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u1", "email": "", "timezone": "Asia/Yekaterinburg"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&got)
+
+	req.Empty(got.User.Email)
+	req.Equal("@alice", got.User.ChatHandle)
+	req.Equal("Asia/Yekaterinburg", got.User.Timezone)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/update").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u1", "timezone": "Mars/Base"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("INVALID_INPUT", errResp.Error.Code)
+}
+
+type DeleteUserResponse struct {
+	UserID              string               `json:"user_id"`
+	PseudonymID         string               `json:"pseudonym_id"`
+	Erased              bool                 `json:"erased"`
+	ReviewReassignments []ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []UnreassignedReview `json:"unreassigned_reviews"`
+}
+
+func TestDeleteUser(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "backend",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var deleted DeleteUserResponse
+	_ = e.POST("/users/delete").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u1"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&deleted)
+
+	req.Equal("u1", deleted.UserID)
+	req.NotEmpty(deleted.PseudonymID)
+	req.NotEqual("u1", deleted.PseudonymID)
+	req.False(deleted.Erased)
+
+	_ = e.GET("/users/get").
+		WithQuery("user_id", "u1").
+		Expect().
+		Status(http.StatusNotFound)
+
+	// The reviewer on the PR leaves with erasure; the only other teammate is
+	// already reviewing, so the review stays under the pseudonym.
+	var erased DeleteUserResponse
+	_ = e.POST("/users/delete").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u2", "erase": true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&erased)
+
+	req.True(erased.Erased)
+	req.Empty(erased.ReviewReassignments)
+	req.Equal([]UnreassignedReview{{PullRequestID: "pr-1", ReviewerID: erased.PseudonymID}}, erased.UnreassignedReviews)
+
+	var merged PullRequest
+	_ = e.POST("/pullRequest/merge").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MergePullRequestRequest{PullRequestID: "pr-1"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&merged)
+
+	req.Equal(deleted.PseudonymID, merged.AuthorID)
+	req.ElementsMatch([]string{erased.PseudonymID, "u3"}, merged.AssignedReviewers)
+
+	var searched SearchUsersResponse
+	_ = e.GET("/users/search").
+		WithQuery("query", "deleted").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&searched)
+
+	req.Empty(searched.Users)
+}
+
+type Unavailability struct {
+	ID                  int64      `json:"id"`
+	UserID              string     `json:"user_id"`
+	StartsAt            time.Time  `json:"starts_at"`
+	EndsAt              time.Time  `json:"ends_at"`
+	Reason              string     `json:"reason"`
+	ReviewsReassignedAt *time.Time `json:"reviews_reassigned_at"`
+}
+
+type UserUnavailabilityResponse struct {
+	UserID         string           `json:"user_id"`
+	Unavailability []Unavailability `json:"unavailability"`
+}
+
+func TestUserUnavailability(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "backend",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var added struct {
+		Unavailability Unavailability `json:"unavailability"`
+	}
+	_ = e.POST("/users/addUnavailability").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{
+			"user_id":   "u2",
+			"starts_at": now.Add(-time.Hour),
+			"ends_at":   now.Add(24 * time.Hour),
+			"reason":    "day off",
+		}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&added)
+
+	req.NotZero(added.Unavailability.ID)
+	req.Equal("u2", added.Unavailability.UserID)
+
+	var created PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&created)
+
+	req.Equal([]string{"u3"}, created.AssignedReviewers)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/addUnavailability").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{
+			"user_id":   "u2",
+			"starts_at": now,
+			"ends_at":   now.Add(-time.Hour),
+		}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Decode(&errResp)
+
+	req.Equal("INVALID_INPUT", errResp.Error.Code)
+
+	var removed UserUnavailabilityResponse
+	_ = e.POST("/users/removeUnavailability").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u2", "id": added.Unavailability.ID}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&removed)
+
+	req.Empty(removed.Unavailability)
+
+	_ = e.POST("/users/removeUnavailability").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{"user_id": "u2", "id": added.Unavailability.ID}).
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+// The e2e environment hands reviews over for absences of 3 days and more.
+func TestUserUnavailability_LongAbsenceHandsOverReviews(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "backend",
+		Members: []TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var created PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u1"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&created)
+	req.Len(created.AssignedReviewers, 2)
+	absentID := created.AssignedReviewers[0]
+
+	now := time.Now().UTC()
+	_ = e.POST("/users/addUnavailability").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(map[string]any{
+			"user_id":   absentID,
+			"starts_at": now.Add(-time.Minute),
+			"ends_at":   now.Add(7 * 24 * time.Hour),
+			"reason":    "vacation",
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	req.Eventually(func() bool {
+		var list UserUnavailabilityResponse
+		_ = e.GET("/users/getUnavailability").
+			WithQuery("user_id", absentID).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Decode(&list)
+		return len(list.Unavailability) == 1 && list.Unavailability[0].ReviewsReassignedAt != nil
+	}, 10*time.Second, 500*time.Millisecond)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", absentID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+
+	req.Empty(reviews.PullRequests)
+}
+
+func TestWorkingHoursStrategy(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	// u2 works around the clock, u3's working day starts in six hours.
+	now := time.Now().UTC()
+	team := map[string]any{
+		"team_name": "distributed",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "Alice", "is_active": true},
+			{
+				"user_id":          "u2",
+				"username":         "Bob",
+				"is_active":        true,
+				"timezone":         "Asia/Tokyo",
+				"work_hours_start": "00:00",
+				"work_hours_end":   "00:00",
+			},
+			{
+				"user_id":          "u3",
+				"username":         "Carol",
+				"is_active":        true,
+				"timezone":         "UTC",
+				"work_hours_start": now.Add(6 * time.Hour).Format("15:04"),
+				"work_hours_end":   now.Add(7 * time.Hour).Format("15:04"),
+			},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var settings TeamSettings
+	_ = e.GET("/team/settings").
+		WithQuery("team_name", "distributed").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&settings)
+
+	settings.ReviewerCount = 1
+	settings.SelectionStrategy = "working_hours"
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusOK)
+
+	for i := range 5 {
+		var created PullRequest
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{
+				PullRequestID:   fmt.Sprintf("pr-%d", i),
+				PullRequestName: "Feature",
+				AuthorID:        "u1",
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().Object().Decode(&created)
+
+		req.Equal([]string{"u2"}, created.AssignedReviewers)
+	}
+
+	var got SetUserActiveResponse
+	_ = e.GET("/users/get").
+		WithQuery("user_id", "u2").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&got)
+
+	req.Equal("00:00", got.User.WorkHoursStart)
+	req.Equal("00:00", got.User.WorkHoursEnd)
+}
+
+func TestSetUserIsActive_TakesOverReviews(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-busy-1", Username: "Busy1", IsActive: true},
+			{UserID: "u-busy-2", Username: "Busy2", IsActive: true},
+			{UserID: "u-back", Username: "Back", IsActive: false},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	for i := range 3 {
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{
+				PullRequestID:   fmt.Sprintf("pr-%d", i),
+				PullRequestName: "Feature",
+				AuthorID:        "u-author",
+			}).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	var resp SetUserActiveResponse
+	_ = e.POST("/users/setIsActive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetUserActiveRequest{UserID: "u-back", IsActive: true, TargetOpenReviews: 2}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+
+	req.True(resp.User.IsActive)
+	req.Len(resp.ReviewReassignments, 2)
+	oldReviewers := make([]string, 0, 2)
+	for _, moved := range resp.ReviewReassignments {
+		req.Equal("u-back", moved.NewReviewerID)
+		oldReviewers = append(oldReviewers, moved.OldReviewerID)
+	}
+	req.ElementsMatch([]string{"u-busy-1", "u-busy-2"}, oldReviewers)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-back").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+
+	req.Len(reviews.PullRequests, 2)
+}
+
+func TestSetUserIsActive_ReassignsOpenReviews(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-a", Username: "A", IsActive: true},
+			{UserID: "u-b", Username: "B", IsActive: true},
+			{UserID: "u-c", Username: "C", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	var created PullRequest
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr-1", PullRequestName: "Feature", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().Object().Decode(&created)
+
+	req.Len(created.AssignedReviewers, 2)
+	leaving := created.AssignedReviewers[0]
+
+	var resp SetUserActiveResponse
+	_ = e.POST("/users/setIsActive").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(SetUserActiveRequest{UserID: leaving, IsActive: false, ReassignOpenReviews: true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+
+	req.False(resp.User.IsActive)
+	req.Empty(resp.UnreassignedReviews)
+	req.Len(resp.ReviewReassignments, 1)
+	req.Equal("pr-1", resp.ReviewReassignments[0].PullRequestID)
+	req.Equal(leaving, resp.ReviewReassignments[0].OldReviewerID)
+	req.NotContains(created.AssignedReviewers, resp.ReviewReassignments[0].NewReviewerID)
+	req.NotEqual("u-author", resp.ReviewReassignments[0].NewReviewerID)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", leaving).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+
+	req.Empty(reviews.PullRequests)
+}
+
+func TestChangeUserTeam(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(Team{
+			TeamName: "team-1",
+			Members: []TeamMember{
+				{UserID: "u-author", Username: "Author", IsActive: true},
+				{UserID: "u-move", Username: "Move", IsActive: true},
+				{UserID: "u-stay", Username: "Stay", IsActive: true},
+			},
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(Team{
+			TeamName: "team-2",
+			Members:  []TeamMember{{UserID: "u-other", Username: "Other", IsActive: true}},
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var settings TeamSettings
+	_ = e.GET("/team/settings").
+		WithQuery("team_name", "team-1").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&settings)
+
+	settings.ReviewerCount = 1
+	settings.ApprovalQuorum = 1
+	settings.AuthorChoosesReviewers = true
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusOK)
+
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{
+			PullRequestID:   "pr-1",
+			PullRequestName: "Feature",
+			AuthorID:        "u-author",
+			ReviewerIDs:     []string{"u-move"},
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var resp ChangeUserTeamResponse
+	_ = e.POST("/users/changeTeam").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ChangeUserTeamRequest{UserID: "u-move", TeamName: "team-2"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+
+	req.Equal("team-2", resp.User.TeamName)
+	req.Equal("team-1", resp.OldTeamName)
+	req.Equal([]ReviewReassignment{{PullRequestID: "pr-1", OldReviewerID: "u-move", NewReviewerID: "u-stay"}}, resp.ReviewReassignments)
+	req.Empty(resp.UnreassignedReviews)
+
+	var team Team
+	_ = e.GET("/team/get").
+		WithQuery("team_name", "team-2").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&team)
+	memberIDs := make([]string, 0, len(team.Members))
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.UserID)
+	}
+	req.ElementsMatch([]string{"u-move", "u-other"}, memberIDs)
+
+	// Moving a user to the team they are already in changes nothing.
+	_ = e.POST("/users/changeTeam").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ChangeUserTeamRequest{UserID: "u-move", TeamName: "team-2"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+	req.Empty(resp.ReviewReassignments)
+	req.Empty(resp.UnreassignedReviews)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/changeTeam").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(ChangeUserTeamRequest{UserID: "u-stay", TeamName: "unknown"}).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().Decode(&errResp)
+	req.Equal("NOT_FOUND", errResp.Error.Code)
+}
+
+func TestMassDeactivateUsers_DryRunPlanToken(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	team := Team{
+		TeamName: "team-1",
+		Members: []TeamMember{
+			{UserID: "u-author", Username: "Author", IsActive: true},
+			{UserID: "u-leave", Username: "Leave", IsActive: true},
+			{UserID: "u-1", Username: "One", IsActive: true},
+		},
+	}
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(team).
+		Expect().
+		Status(http.StatusCreated)
+
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr1", PullRequestName: "Feature", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	// u-2 joins after pr1 got both reviewers, so the random pick of who takes
+	// over the review of u-leave has two options.
+	_ = e.POST("/team/addMembers").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(AddTeamMembersRequest{
+			TeamName: "team-1",
+			Members:  []TeamMember{{UserID: "u-2", Username: "Two", IsActive: true}},
+		}).
+		Expect().
+		Status(http.StatusOK)
+
+	var preview MassDeactivateUsersResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "team-1", UserIDs: []string{"u-leave"}, DryRun: true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&preview)
+
+	req.True(preview.DryRun)
+	req.Len(preview.PlanToken, 32)
+	req.Len(preview.ReviewReassignments, 1)
+	req.Equal("u-leave", preview.ReviewReassignments[0].OldReviewerID)
+
+	var user SetUserActiveResponse
+	_ = e.GET("/users/get").
+		WithQuery("user_id", "u-leave").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&user)
+	req.True(user.User.IsActive)
+
+	var applied MassDeactivateUsersResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "team-1", UserIDs: []string{"u-leave"}, PlanToken: preview.PlanToken}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&applied)
+
+	// The apply call makes exactly the previewed pick.
+	req.False(applied.DryRun)
+	req.Equal(preview.ReviewReassignments, applied.ReviewReassignments)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", applied.ReviewReassignments[0].NewReviewerID).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+	req.Len(reviews.PullRequests, 1)
+
+	// A token is applied at most once.
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "team-1", UserIDs: []string{"u-leave"}, PlanToken: preview.PlanToken}).
+		Expect().
+		Status(http.StatusNotFound)
+
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "team-1", UserIDs: []string{"u-1"}, DryRun: true}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&preview)
+
+	// pr2 gives u-1 another review, so the previewed plan is outdated.
+	_ = e.POST("/pullRequest/create").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(CreatePullRequestRequest{PullRequestID: "pr2", PullRequestName: "Feature 2", AuthorID: "u-author"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{TeamName: "team-1", UserIDs: []string{"u-1"}, PlanToken: preview.PlanToken}).
+		Expect().
+		Status(http.StatusConflict).
+		JSON().Object().Decode(&errResp)
+	req.Equal("PLAN_OUTDATED", errResp.Error.Code)
+}
+
+func TestMassDeactivateUsers_AcrossTeams(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	for _, name := range []string{"team-a", "team-b"} {
+		_ = e.POST("/team/add").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(Team{
+				TeamName: name,
+				Members: []TeamMember{
+					{UserID: name + "-author", Username: "Author", IsActive: true},
+					{UserID: name + "-leave", Username: "Leave", IsActive: true},
+					{UserID: name + "-stay", Username: "Stay", IsActive: true},
+				},
+			}).
+			Expect().
+			Status(http.StatusCreated)
+
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{PullRequestID: name + "-pr", PullRequestName: "Feature", AuthorID: name + "-author"}).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	var resp MassDeactivateUsersResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{UserIDs: []string{"team-b-leave", "team-a-leave"}}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+
+	req.Empty(resp.TeamName)
+	req.Len(resp.ReviewReassignments, 2)
+	req.Len(resp.Teams, 2)
+	for i, name := range []string{"team-b", "team-a"} {
+		group := resp.Teams[i]
+		req.Equal(name, group.TeamName)
+		req.Equal([]string{name + "-leave"}, group.UserIDs)
+		req.Len(group.ReviewReassignments, 1)
+		req.Equal(name+"-pr", group.ReviewReassignments[0].PullRequestID)
+		req.Equal(name+"-leave", group.ReviewReassignments[0].OldReviewerID)
+		req.Empty(group.UnreassignedReviews)
+	}
+
+	for _, id := range []string{"team-a-leave", "team-b-leave"} {
+		var got SetUserActiveResponse
+		_ = e.GET("/users/get").
+			WithQuery("user_id", id).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Decode(&got)
+		req.False(got.User.IsActive)
+	}
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/massDeactivate").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(MassDeactivateUsersRequest{UserIDs: []string{"team-a-stay", "unknown"}}).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().Decode(&errResp)
+	req.Equal("NOT_FOUND", errResp.Error.Code)
+}
+
+func TestHandoverReviews(t *testing.T) {
+	req := require.New(t)
+	e := newExpect(t)
+
+	cleanup, err := setupPostgres()
+	req.NoError(err)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+
+	_ = e.POST("/team/add").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(Team{
+			TeamName: "team-1",
+			Members: []TeamMember{
+				{UserID: "u-author", Username: "Author", IsActive: true},
+				{UserID: "u-from", Username: "From", IsActive: true},
+				{UserID: "u-to", Username: "To", IsActive: true},
+				{UserID: "u-gone", Username: "Gone", IsActive: false},
+			},
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	var settings TeamSettings
+	_ = e.GET("/team/settings").
+		WithQuery("team_name", "team-1").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&settings)
+
+	settings.ReviewerCount = 1
+	settings.ApprovalQuorum = 1
+	settings.AuthorChoosesReviewers = true
+	_ = e.PUT("/team/settings").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("If-Match", `"1"`).
+		WithJSON(settings).
+		Expect().
+		Status(http.StatusOK)
+
+	// pr-2 is authored by the target, so it has to stay with u-from.
+	for id, author := range map[string]string{"pr-1": "u-author", "pr-2": "u-to"} {
+		_ = e.POST("/pullRequest/create").
+			WithHeader("Content-Type", "application/json").
+			WithJSON(CreatePullRequestRequest{
+				PullRequestID:   id,
+				PullRequestName: "Feature",
+				AuthorID:        author,
+				ReviewerIDs:     []string{"u-from"},
+			}).
+			Expect().
+			Status(http.StatusCreated)
+	}
+
+	var resp HandoverReviewsResponse
+	_ = e.POST("/users/handoverReviews").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(HandoverReviewsRequest{FromUserID: "u-from", ToUserID: "u-to"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&resp)
+
+	req.Equal("all", resp.Scope)
+	req.Equal([]ReviewReassignment{{PullRequestID: "pr-1", OldReviewerID: "u-from", NewReviewerID: "u-to"}}, resp.Moved)
+	req.Equal([]SkippedReview{{PullRequestID: "pr-2", Reason: "author"}}, resp.Skipped)
+
+	var reviews UserReviewListResponse
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-to").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+	req.Len(reviews.PullRequests, 1)
+	req.Equal("pr-1", reviews.PullRequests[0].PullRequestID)
+
+	_ = e.GET("/users/getReview").
+		WithQuery("user_id", "u-from").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Decode(&reviews)
+	req.Len(reviews.PullRequests, 1)
+	req.Equal("pr-2", reviews.PullRequests[0].PullRequestID)
+
+	var errResp ErrorResponse
+	_ = e.POST("/users/handoverReviews").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(HandoverReviewsRequest{FromUserID: "u-from", ToUserID: "u-gone"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().Decode(&errResp)
+	req.Equal("INVALID_INPUT", errResp.Error.Code)
+
+	_ = e.POST("/users/handoverReviews").
+		WithHeader("Content-Type", "application/json").
+		WithJSON(HandoverReviewsRequest{FromUserID: "unknown", ToUserID: "u-to"}).
+		Expect().
+		Status(http.StatusNotFound).
+		JSON().Object().Decode(&errResp)
+	req.Equal("NOT_FOUND", errResp.Error.Code)
+}
@@ -20,16 +20,43 @@ type HTTPConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
 	WriteTimeout time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
 	IdleTimeout  time.Duration `mapstructure:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownDrainDelay is how long /readyz reports not-ready before the server stops accepting connections.
+	ShutdownDrainDelay time.Duration `mapstructure:"HTTP_SHUTDOWN_DRAIN_DELAY"`
+}
+
+type TracingConfig struct {
+	Exporter     string `mapstructure:"TRACING_EXPORTER"` // none, stdout or otlp
+	OTLPEndpoint string `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	ServiceName  string `mapstructure:"TRACING_SERVICE_NAME"`
+}
+
+type ReviewConfig struct {
+	// FallbackDepth limits how far reviewer selection looks outside the author's
+	// team: 0 - own team only, 1 - also sibling teams, 2 - also the parent team.
+	FallbackDepth int `mapstructure:"REVIEW_FALLBACK_DEPTH"`
+	// AbsenceReassignDays enables handing over open reviews of users whose
+	// absence of at least this many days has started; 0 disables it.
+	AbsenceReassignDays int `mapstructure:"REVIEW_ABSENCE_REASSIGN_DAYS"`
+	// AbsenceCheckInterval is how often started absences are looked up.
+	AbsenceCheckInterval time.Duration `mapstructure:"REVIEW_ABSENCE_CHECK_INTERVAL"`
 }
 
 type Config struct {
 	Database DatabaseConfig
 	HTTP     HTTPConfig
+	Tracing  TracingConfig
+	Review   ReviewConfig
 }
 
 func LoadConfig() (*Config, error) {
 	v := viper.New()
 	v.AutomaticEnv()
+	v.SetDefault("HTTP_SHUTDOWN_DRAIN_DELAY", "5s")
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("TRACING_SERVICE_NAME", "reviewer-service")
+	v.SetDefault("REVIEW_FALLBACK_DEPTH", 2)
+	v.SetDefault("REVIEW_ABSENCE_REASSIGN_DAYS", 0)
+	v.SetDefault("REVIEW_ABSENCE_CHECK_INTERVAL", "1m")
 
 	cfg := &Config{
 		Database: DatabaseConfig{
@@ -40,10 +67,21 @@ func LoadConfig() (*Config, error) {
 			Name:     v.GetString("DATABASE_NAME"),
 		},
 		HTTP: HTTPConfig{
-			Address:      v.GetString("HTTP_ADDRESS"),
-			ReadTimeout:  v.GetDuration("HTTP_READ_TIMEOUT"),
-			WriteTimeout: v.GetDuration("HTTP_WRITE_TIMEOUT"),
-			IdleTimeout:  v.GetDuration("HTTP_IDLE_TIMEOUT"),
+			Address:            v.GetString("HTTP_ADDRESS"),
+			ReadTimeout:        v.GetDuration("HTTP_READ_TIMEOUT"),
+			WriteTimeout:       v.GetDuration("HTTP_WRITE_TIMEOUT"),
+			IdleTimeout:        v.GetDuration("HTTP_IDLE_TIMEOUT"),
+			ShutdownDrainDelay: v.GetDuration("HTTP_SHUTDOWN_DRAIN_DELAY"),
+		},
+		Tracing: TracingConfig{
+			Exporter:     v.GetString("TRACING_EXPORTER"),
+			OTLPEndpoint: v.GetString("TRACING_OTLP_ENDPOINT"),
+			ServiceName:  v.GetString("TRACING_SERVICE_NAME"),
+		},
+		Review: ReviewConfig{
+			FallbackDepth:        v.GetInt("REVIEW_FALLBACK_DEPTH"),
+			AbsenceReassignDays:  v.GetInt("REVIEW_ABSENCE_REASSIGN_DAYS"),
+			AbsenceCheckInterval: v.GetDuration("REVIEW_ABSENCE_CHECK_INTERVAL"),
 		},
 	}
 
@@ -74,6 +112,24 @@ func LoadConfig() (*Config, error) {
 	if cfg.HTTP.IdleTimeout == 0 {
 		return nil, fmt.Errorf("HTTP_IDLE_TIMEOUT is required")
 	}
+	switch cfg.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if cfg.Tracing.OTLPEndpoint == "" {
+			return nil, fmt.Errorf("TRACING_OTLP_ENDPOINT is required for otlp exporter")
+		}
+	default:
+		return nil, fmt.Errorf("TRACING_EXPORTER must be one of none, stdout, otlp")
+	}
+	if cfg.Review.FallbackDepth < 0 || cfg.Review.FallbackDepth > 2 {
+		return nil, fmt.Errorf("REVIEW_FALLBACK_DEPTH must be between 0 and 2")
+	}
+	if cfg.Review.AbsenceReassignDays < 0 {
+		return nil, fmt.Errorf("REVIEW_ABSENCE_REASSIGN_DAYS must not be negative")
+	}
+	if cfg.Review.AbsenceReassignDays > 0 && cfg.Review.AbsenceCheckInterval <= 0 {
+		return nil, fmt.Errorf("REVIEW_ABSENCE_CHECK_INTERVAL must be positive")
+	}
 
 	return cfg, nil
 }
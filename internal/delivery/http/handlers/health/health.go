@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+const checkTimeout = 2 * time.Second
+
+type Check struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+type Delivery struct {
+	checks       []Check
+	shuttingDown atomic.Bool
+}
+
+func NewDelivery(checks ...Check) *Delivery {
+	return &Delivery{checks: checks}
+}
+
+func (d *Delivery) RegisterRoutes(r *mux.Router) {
+	r.HandleFunc("/healthz", d.Liveness).Methods(http.MethodGet)
+	r.HandleFunc("/readyz", d.Readiness).Methods(http.MethodGet)
+}
+
+// SetShuttingDown makes readiness fail so the orchestrator stops routing
+// traffic while in-flight requests are drained.
+func (d *Delivery) SetShuttingDown() {
+	d.shuttingDown.Store(true)
+}
+
+func (d *Delivery) Liveness(w http.ResponseWriter, r *http.Request) {
+	l := logger.FromCtx(r.Context())
+
+	if err := httputil.WriteJSON(w, http.StatusOK, entity.HealthResponse{Status: entity.HealthStatusOK}); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+	}
+}
+
+func (d *Delivery) Readiness(w http.ResponseWriter, r *http.Request) {
+	l := logger.FromCtx(r.Context())
+
+	resp := entity.HealthResponse{
+		Status: entity.HealthStatusOK,
+		Checks: make(map[string]string, len(d.checks)),
+	}
+	status := http.StatusOK
+
+	if d.shuttingDown.Load() {
+		resp.Status = entity.HealthStatusUnavailable
+		resp.Checks["shutdown"] = "server is shutting down"
+		status = http.StatusServiceUnavailable
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), checkTimeout)
+	defer cancel()
+
+	for _, check := range d.checks {
+		if err := check.Fn(ctx); err != nil {
+			l.Warn("readiness check failed", zap.String("check", check.Name), zap.Error(err))
+			resp.Status = entity.HealthStatusUnavailable
+			resp.Checks[check.Name] = err.Error()
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		resp.Checks[check.Name] = entity.HealthStatusOK
+	}
+
+	if err := httputil.WriteJSON(w, status, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+	}
+}
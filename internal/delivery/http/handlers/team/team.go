@@ -2,13 +2,11 @@ package team
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
-	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
@@ -16,6 +14,15 @@ import (
 type UseCase interface {
 	CreateTeam(ctx context.Context, team *entity.Team) error
 	GetByName(ctx context.Context, name string) (*entity.Team, error)
+	AddMembers(ctx context.Context, req *entity.AddTeamMembersRequest) (*entity.Team, error)
+	RemoveMembers(ctx context.Context, req *entity.RemoveTeamMembersRequest) (*entity.RemoveTeamMembersResponse, error)
+	Rename(ctx context.Context, req *entity.RenameTeamRequest) (*entity.Team, error)
+	Archive(ctx context.Context, req *entity.ArchiveTeamRequest) (*entity.ArchiveTeamResponse, error)
+	Rebalance(ctx context.Context, req *entity.RebalanceTeamRequest) (*entity.RebalanceTeamResponse, error)
+	SetParent(ctx context.Context, req *entity.SetTeamParentRequest) (*entity.Team, error)
+	SetLeadEscalation(ctx context.Context, req *entity.SetLeadEscalationRequest) (*entity.Team, error)
+	GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error)
+	UpdateSettings(ctx context.Context, req *entity.UpdateTeamSettingsRequest, version int) (*entity.TeamSettings, error)
 }
 
 type Delivery struct {
@@ -30,6 +37,15 @@ func (d *Delivery) RegisterRoutes(r *mux.Router) {
 	s := r.PathPrefix("/team").Subrouter()
 	s.HandleFunc("/add", d.CreateTeam).Methods(http.MethodPost)
 	s.HandleFunc("/get", d.GetTeam).Methods(http.MethodGet)
+	s.HandleFunc("/addMembers", d.AddMembers).Methods(http.MethodPost)
+	s.HandleFunc("/removeMembers", d.RemoveMembers).Methods(http.MethodPost)
+	s.HandleFunc("/rename", d.Rename).Methods(http.MethodPost)
+	s.HandleFunc("/archive", d.Archive).Methods(http.MethodPost)
+	s.HandleFunc("/rebalance", d.Rebalance).Methods(http.MethodPost)
+	s.HandleFunc("/setParent", d.SetParent).Methods(http.MethodPost)
+	s.HandleFunc("/setLeadEscalation", d.SetLeadEscalation).Methods(http.MethodPost)
+	s.HandleFunc("/settings", d.GetSettings).Methods(http.MethodGet)
+	s.HandleFunc("/settings", d.UpdateSettings).Methods(http.MethodPut)
 }
 
 func (d *Delivery) CreateTeam(w http.ResponseWriter, r *http.Request) {
@@ -39,23 +55,16 @@ func (d *Delivery) CreateTeam(w http.ResponseWriter, r *http.Request) {
 
 	if err := httputil.ReadJSON(r, &in); err != nil {
 		l.Warn("failed to read request", zap.Error(err))
-		if httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body") != nil {
+		if httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body") != nil {
 			l.Error("failed to read request", zap.Error(err))
 			return
 		}
 		return
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(in); err != nil {
-		var errValid validator.ValidationErrors
-		ok := errors.As(err, &errValid)
-		if !ok {
-			l.Error("validation error is not of type ValidationErrors", zap.Error(err))
-			httputil.WriteInternalServerError(w, err)
-			return
-		}
-		if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "validation error: "+errValid.Error()); writeErr != nil {
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
 			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
@@ -65,24 +74,8 @@ func (d *Delivery) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	team := &in
 
 	if err := d.uc.CreateTeam(ctx, team); err != nil {
-		switch {
-		case errors.Is(err, entity.ErrAlreadyExists):
-			if writeErr := httputil.WriteAPIError(w, http.StatusConflict, entity.ErrorCodeTeamExists, "team_name already exists"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		case errors.Is(err, entity.ErrDuplicateUserIDs):
-			if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "duplicate user IDs in members"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		case errors.Is(err, entity.ErrNotFound):
-			if writeErr := httputil.WriteAPIError(w, http.StatusNotFound, entity.ErrorCodeNotFound, "one or more members not found"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		default:
-			httputil.WriteInternalServerError(w, err)
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 		}
 		return
 	}
@@ -90,7 +83,7 @@ func (d *Delivery) CreateTeam(w http.ResponseWriter, r *http.Request) {
 	resp := entity.CreateTeamResponse{Team: team}
 	if err := httputil.WriteJSON(w, http.StatusCreated, resp); err != nil {
 		l.Warn("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 
@@ -101,7 +94,7 @@ func (d *Delivery) GetTeam(w http.ResponseWriter, r *http.Request) {
 	l := logger.FromCtx(ctx)
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
-		if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "team_name is required"); writeErr != nil {
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "team_name is required"); writeErr != nil {
 			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
@@ -110,21 +103,360 @@ func (d *Delivery) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 	team, err := d.uc.GetByName(ctx, teamName)
 	if err != nil {
-		if errors.Is(err, entity.ErrNotFound) {
-			if writeErr := httputil.WriteAPIError(w, http.StatusNotFound, entity.ErrorCodeNotFound, "team not found"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, team); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) AddMembers(w http.ResponseWriter, r *http.Request) {
+	var in entity.AddTeamMembersRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
+		return
+	}
 
-		httputil.WriteInternalServerError(w, err)
+	team, err := d.uc.AddMembers(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
 		return
 	}
 
-	if err = httputil.WriteJSON(w, http.StatusOK, team); err != nil {
+	resp := entity.AddTeamMembersResponse{Team: team}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) RemoveMembers(w http.ResponseWriter, r *http.Request) {
+	var in entity.RemoveTeamMembersRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.RemoveMembers(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) Rebalance(w http.ResponseWriter, r *http.Request) {
+	var in entity.RebalanceTeamRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.Rebalance(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) Rename(w http.ResponseWriter, r *http.Request) {
+	var in entity.RenameTeamRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	team, err := d.uc.Rename(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.RenameTeamResponse{Team: team}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) Archive(w http.ResponseWriter, r *http.Request) {
+	var in entity.ArchiveTeamRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.Archive(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) SetParent(w http.ResponseWriter, r *http.Request) {
+	var in entity.SetTeamParentRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	team, err := d.uc.SetParent(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.SetTeamParentResponse{Team: team}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) SetLeadEscalation(w http.ResponseWriter, r *http.Request) {
+	var in entity.SetLeadEscalationRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	team, err := d.uc.SetLeadEscalation(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.SetLeadEscalationResponse{Team: team}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) GetSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "team_name is required"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	settings, err := d.uc.GetSettings(ctx, teamName)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	httputil.SetETag(w, settings.Version)
+	if err = httputil.WriteJSON(w, http.StatusOK, settings); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var in entity.UpdateTeamSettingsRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	version, ok := httputil.IfMatchVersion(r)
+	if !ok {
+		if writeErr := httputil.WriteError(w, r, entity.ErrIfMatchRequired); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	settings, err := d.uc.UpdateSettings(ctx, &in, version)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	httputil.SetETag(w, settings.Version)
+	if err = httputil.WriteJSON(w, http.StatusOK, settings); err != nil {
 		l.Error("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 }
@@ -2,24 +2,34 @@ package user
 
 import (
 	"context"
-	"errors"
 	"net/http"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
-	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 )
 
 type UseCase interface {
-	SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error)
+	SetIsActive(ctx context.Context, req *entity.SetUserActiveRequest) (*entity.SetUserActiveResponse, error)
 	GetReviewList(ctx context.Context, userID string) (*entity.UserReviewListResponse, error)
 	GetStatistics(ctx context.Context) (*entity.StatsByUsersResponse, error)
 	MassDeactivateUsers(ctx context.Context, in *entity.MassDeactivateUsersRequest) (*entity.MassDeactivateUsersResponse, error)
+	ChangeTeam(ctx context.Context, req *entity.ChangeUserTeamRequest) (*entity.ChangeUserTeamResponse, error)
+	HandoverReviews(ctx context.Context, req *entity.HandoverReviewsRequest) (*entity.HandoverReviewsResponse, error)
+	GetUser(ctx context.Context, userID string) (*entity.User, error)
+	CreateUser(ctx context.Context, req *entity.CreateUserRequest) (*entity.User, error)
+	UpdateUser(ctx context.Context, req *entity.UpdateUserRequest) (*entity.User, error)
+	SearchUsers(ctx context.Context, req *entity.SearchUsersRequest) (*entity.SearchUsersResponse, error)
+	DeleteUser(ctx context.Context, req *entity.DeleteUserRequest) (*entity.DeleteUserResponse, error)
+	AddUnavailability(ctx context.Context, req *entity.AddUnavailabilityRequest) (*entity.Unavailability, error)
+	GetUnavailability(ctx context.Context, userID string) (*entity.UserUnavailabilityResponse, error)
+	RemoveUnavailability(ctx context.Context, req *entity.RemoveUnavailabilityRequest) (*entity.UserUnavailabilityResponse, error)
 }
 
+const defaultSearchLimit = 20
+
 type Delivery struct {
 	uc UseCase
 }
@@ -34,6 +44,16 @@ func (d *Delivery) RegisterRoutes(r *mux.Router) {
 	s.HandleFunc("/getReview", d.GetReviewList).Methods("GET")
 	s.HandleFunc("/getStatistics", d.GetStatistics).Methods("GET")
 	s.HandleFunc("/massDeactivate", d.Deactivate).Methods("POST")
+	s.HandleFunc("/changeTeam", d.ChangeTeam).Methods("POST")
+	s.HandleFunc("/handoverReviews", d.HandoverReviews).Methods("POST")
+	s.HandleFunc("/get", d.GetUser).Methods("GET")
+	s.HandleFunc("/create", d.CreateUser).Methods("POST")
+	s.HandleFunc("/update", d.UpdateUser).Methods("POST")
+	s.HandleFunc("/search", d.SearchUsers).Methods("GET")
+	s.HandleFunc("/delete", d.DeleteUser).Methods("POST")
+	s.HandleFunc("/addUnavailability", d.AddUnavailability).Methods("POST")
+	s.HandleFunc("/getUnavailability", d.GetUnavailability).Methods("GET")
+	s.HandleFunc("/removeUnavailability", d.RemoveUnavailability).Methods("POST")
 }
 
 func (d *Delivery) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -43,50 +63,33 @@ func (d *Delivery) SetIsActive(w http.ResponseWriter, r *http.Request) {
 
 	if err := httputil.ReadJSON(r, &in); err != nil {
 		l.Warn("failed to read request", zap.Error(err))
-		if httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body") != nil {
+		if httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body") != nil {
 			l.Error("failed to write error", zap.Error(err))
 			return
 		}
 		return
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(in); err != nil {
-		var errValid validator.ValidationErrors
-		ok := errors.As(err, &errValid)
-		if !ok {
-			l.Error("validation error is not of type ValidationErrors", zap.Error(err))
-			httputil.WriteInternalServerError(w, err)
-			return
-		}
-		if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "validation error: "+errValid.Error()); writeErr != nil {
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
 			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
 		return
 	}
 
-	updatedUser, err := d.uc.SetIsActive(ctx, in.UserID, *in.IsActive)
+	resp, err := d.uc.SetIsActive(ctx, &in)
 	if err != nil {
-		if errors.Is(err, entity.ErrNotFound) {
-			l.Warn("failed to find user", zap.Error(err))
-			if writeErr := httputil.WriteAPIError(w, http.StatusNotFound, entity.ErrorCodeNotFound, "resource not found"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-			return
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 		}
-
-		httputil.WriteInternalServerError(w, err)
 		return
 	}
 
-	var resp entity.SetUserActiveResponse
-	resp.User = updatedUser
-
 	if err := httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
 		l.Error("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 }
@@ -96,7 +99,7 @@ func (d *Delivery) GetReviewList(w http.ResponseWriter, r *http.Request) {
 	l := logger.FromCtx(ctx)
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
-		if err := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "missing user_id parameter"); err != nil {
+		if err := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "missing user_id parameter"); err != nil {
 			l.Error("failed to write error", zap.Error(err))
 			return
 		}
@@ -105,20 +108,15 @@ func (d *Delivery) GetReviewList(w http.ResponseWriter, r *http.Request) {
 
 	reviewList, err := d.uc.GetReviewList(ctx, userID)
 	if err != nil {
-		if errors.Is(err, entity.ErrNotFound) {
-			if writeErr := httputil.WriteAPIError(w, http.StatusNotFound, entity.ErrorCodeNotFound, "user_id not found"); writeErr != nil {
-				l.Warn("failed to write error", zap.Error(writeErr))
-				return
-			}
-			return
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 		}
-		httputil.WriteInternalServerError(w, err)
 		return
 	}
 
 	if err = httputil.WriteJSON(w, http.StatusOK, reviewList); err != nil {
 		l.Error("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 
@@ -130,13 +128,15 @@ func (d *Delivery) GetStatistics(w http.ResponseWriter, r *http.Request) {
 
 	stats, err := d.uc.GetStatistics(ctx)
 	if err != nil {
-		httputil.WriteInternalServerError(w, err)
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
 		return
 	}
 
 	if err = httputil.WriteJSON(w, http.StatusOK, stats); err != nil {
 		l.Error("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 }
@@ -148,56 +148,400 @@ func (d *Delivery) Deactivate(w http.ResponseWriter, r *http.Request) {
 	var in *entity.MassDeactivateUsersRequest
 	if err := httputil.ReadJSON(r, &in); err != nil {
 		l.Warn("failed to read request", zap.Error(err))
-		if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
 			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
 		return
 	}
 
-	validate := validator.New()
-	if err := validate.Struct(in); err != nil {
-		var errValid validator.ValidationErrors
-		ok := errors.As(err, &errValid)
-		if !ok {
-			l.Error("validation error is not of type ValidationErrors", zap.Error(err))
-			httputil.WriteInternalServerError(w, err)
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
-		if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "validation error: "+errValid.Error()); writeErr != nil {
-			l.Warn("failed to write error", zap.Error(writeErr))
+		return
+	}
+
+	resp, err := d.uc.MassDeactivateUsers(ctx, in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) ChangeTeam(w http.ResponseWriter, r *http.Request) {
+	var in entity.ChangeUserTeamRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 			return
 		}
 		return
 	}
 
-	resp, err := d.uc.MassDeactivateUsers(ctx, in)
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.ChangeTeam(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) HandoverReviews(w http.ResponseWriter, r *http.Request) {
+	var in entity.HandoverReviewsRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.HandoverReviews(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) GetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		if err := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "missing user_id parameter"); err != nil {
+			l.Error("failed to write error", zap.Error(err))
+			return
+		}
+		return
+	}
+
+	user, err := d.uc.GetUser(ctx, userID)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.GetUserResponse{User: user}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var in entity.CreateUserRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	user, err := d.uc.CreateUser(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.CreateUserResponse{User: user}
+	if err = httputil.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	var in entity.UpdateUserRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	user, err := d.uc.UpdateUser(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.UpdateUserResponse{User: user}
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	in := entity.SearchUsersRequest{Query: r.URL.Query().Get("query")}
+	var err error
+	if in.Limit, err = httputil.QueryInt(r, "limit", defaultSearchLimit); err != nil {
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "limit must be an integer"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+	if in.Offset, err = httputil.QueryInt(r, "offset", 0); err != nil {
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "offset must be an integer"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.SearchUsers(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	var in entity.DeleteUserRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.DeleteUser(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var in entity.AddUnavailabilityRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	unavailability, err := d.uc.AddUnavailability(ctx, &in)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	resp := entity.AddUnavailabilityResponse{Unavailability: unavailability}
+	if err = httputil.WriteJSON(w, http.StatusCreated, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) GetUnavailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		if err := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "missing user_id parameter"); err != nil {
+			l.Error("failed to write error", zap.Error(err))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.GetUnavailability(ctx, userID)
+	if err != nil {
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+		}
+		return
+	}
+
+	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
+		l.Error("failed to write response", zap.Error(err))
+		httputil.WriteInternalServerError(w, r, err)
+		return
+	}
+}
+
+func (d *Delivery) RemoveUnavailability(w http.ResponseWriter, r *http.Request) {
+	var in entity.RemoveUnavailabilityRequest
+	ctx := r.Context()
+	l := logger.FromCtx(ctx)
+
+	if err := httputil.ReadJSON(r, &in); err != nil {
+		l.Warn("failed to read request", zap.Error(err))
+		if writeErr := httputil.WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "invalid JSON body"); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	if err := httputil.Validate(in); err != nil {
+		l.Warn("request validation failed", zap.Error(err))
+		if writeErr := httputil.WriteValidationError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
+			return
+		}
+		return
+	}
+
+	resp, err := d.uc.RemoveUnavailability(ctx, &in)
 	if err != nil {
-		switch {
-		case errors.Is(err, entity.ErrDuplicateUserIDs):
-			if writeErr := httputil.WriteAPIError(w, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "duplicate user IDs in request"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		case errors.Is(err, entity.ErrNotFound):
-			if writeErr := httputil.WriteAPIError(w, http.StatusNotFound, entity.ErrorCodeNotFound, "resource not found"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		case errors.Is(err, entity.ErrUsersNotInSameTeam):
-			if writeErr := httputil.WriteAPIError(w, http.StatusInternalServerError, entity.ErrorCodeNotSameTeam, "deactivated users should be from the same team"); writeErr != nil {
-				l.Error("failed to write error", zap.Error(writeErr))
-				return
-			}
-		default:
-			httputil.WriteInternalServerError(w, err)
+		if writeErr := httputil.WriteError(w, r, err); writeErr != nil {
+			l.Error("failed to write error", zap.Error(writeErr))
 		}
 		return
 	}
 
 	if err = httputil.WriteJSON(w, http.StatusOK, resp); err != nil {
 		l.Error("failed to write response", zap.Error(err))
-		httputil.WriteInternalServerError(w, err)
+		httputil.WriteInternalServerError(w, r, err)
 		return
 	}
 }
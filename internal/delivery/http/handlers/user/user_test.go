@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type setIsActiveUseCase struct {
+	UseCase
+	called bool
+}
+
+func (uc *setIsActiveUseCase) SetIsActive(_ context.Context, req *entity.SetUserActiveRequest) (*entity.SetUserActiveResponse, error) {
+	uc.called = true
+	return &entity.SetUserActiveResponse{User: &entity.User{ID: req.UserID, IsActive: *req.IsActive}}, nil
+}
+
+func TestDelivery_SetIsActive_Options(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantParams []*entity.InvalidParam
+	}{
+		{
+			name:       "target on activation",
+			body:       `{"user_id": "u1", "is_active": true, "target_open_reviews": 3}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "reassign on deactivation",
+			body:       `{"user_id": "u1", "is_active": false, "reassign_open_reviews": true}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "target on deactivation",
+			body:       `{"user_id": "u1", "is_active": false, "target_open_reviews": 3}`,
+			wantStatus: http.StatusBadRequest,
+			wantParams: []*entity.InvalidParam{{Name: "target_open_reviews", Reason: "excluded_if", Param: "IsActive false"}},
+		},
+		{
+			name:       "reassign on activation",
+			body:       `{"user_id": "u1", "is_active": true, "reassign_open_reviews": true}`,
+			wantStatus: http.StatusBadRequest,
+			wantParams: []*entity.InvalidParam{{Name: "reassign_open_reviews", Reason: "excluded_if", Param: "IsActive true"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &setIsActiveUseCase{}
+			router := mux.NewRouter()
+			NewUserDelivery(uc).RegisterRoutes(router)
+
+			r := httptest.NewRequest(http.MethodPost, "/users/setIsActive", strings.NewReader(tt.body))
+			r.Header.Set("Accept", httputil.ContentTypeProblemJSON)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, uc.called)
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			var problem entity.ProblemDetails
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, entity.ErrorCodeInvalidInput, problem.Code)
+			assert.Equal(t, tt.wantParams, problem.InvalidParams)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
 	"github.com/gorilla/mux"
 	"github.com/rs/xid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -52,6 +54,12 @@ func Middleware() mux.MiddlewareFunc {
 
 			l = l.With(zap.String(string(requestIDContextKey), requestID))
 
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(attribute.String("http.request_id", requestID))
+			if spanCtx := span.SpanContext(); spanCtx.HasTraceID() {
+				l = l.With(zap.String("trace_id", spanCtx.TraceID().String()))
+			}
+
 			w.Header().Add(string(requestIDContextKey), requestID)
 
 			lrw := newLoggingResponseWriter(w)
@@ -0,0 +1,57 @@
+package accesslog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware_RequestIDSpanAttribute(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+	}{
+		{name: "from header", requestID: "req-42"},
+		{name: "generated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			r := mux.NewRouter()
+			r.Use(otelmux.Middleware("test", otelmux.WithTracerProvider(provider)))
+			r.Use(Middleware())
+			r.HandleFunc("/team/get", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.requestID != "" {
+				req.Header.Set(string(requestIDContextKey), tt.requestID)
+			}
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(string(requestIDContextKey))
+			require.NotEmpty(t, requestID)
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, requestID)
+			}
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Contains(t, spans[0].Attributes(), attribute.String("http.request_id", requestID))
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package httpmetrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/metrics"
+	"github.com/gorilla/mux"
+)
+
+const unknownRoute = "unknown"
+
+type statusResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (srw *statusResponseWriter) WriteHeader(code int) {
+	srw.statusCode = code
+	srw.ResponseWriter.WriteHeader(code)
+}
+
+// Middleware records request count and latency labelled by the route template
+// (e.g. /team/get) instead of the raw URL to keep label cardinality bounded.
+func Middleware(m *metrics.Metrics) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := unknownRoute
+			if current := mux.CurrentRoute(r); current != nil {
+				if tpl, err := current.GetPathTemplate(); err == nil {
+					route = tpl
+				}
+			}
+
+			srw := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			defer func(start time.Time) {
+				m.ObserveRequest(r.Method, route, srw.statusCode, time.Since(start))
+			}(time.Now())
+
+			next.ServeHTTP(srw, r)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package recovery
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Middleware turns a panic in a handler into an INTERNAL error response. It
+// must run after accesslog so the logged stack carries the request ID.
+func Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				// net/http uses this value to abort a response silently.
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				l := logger.FromCtx(r.Context())
+				l.Error("panic recovered",
+					zap.Any("panic", rec),
+					zap.ByteString("stack", debug.Stack()),
+				)
+				tracing.RecordError(trace.SpanFromContext(r.Context()), fmt.Errorf("panic: %v", rec))
+
+				if err := httputil.WriteAPIError(w, r, http.StatusInternalServerError, entity.ErrorCodeInternal, "internal server error"); err != nil {
+					l.Error("failed to write error", zap.Error(err))
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
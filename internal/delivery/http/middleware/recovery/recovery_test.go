@@ -0,0 +1,94 @@
+package recovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/httputil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		handler         http.HandlerFunc
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantCode        entity.ErrorCode
+	}{
+		{
+			name: "no panic",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "panic",
+			handler: func(http.ResponseWriter, *http.Request) {
+				panic("boom")
+			},
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: httputil.ContentTypeJSON,
+			wantCode:        entity.ErrorCodeInternal,
+		},
+		{
+			name: "panic with problem json",
+			handler: func(http.ResponseWriter, *http.Request) {
+				var m map[string]int
+				m["x"]++
+			},
+			accept:          httputil.ContentTypeProblemJSON,
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: httputil.ContentTypeProblemJSON,
+			wantCode:        entity.ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			require.NotPanics(t, func() {
+				Middleware()(tt.handler).ServeHTTP(w, r)
+			})
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantCode == "" {
+				return
+			}
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+
+			var body struct {
+				Code  entity.ErrorCode `json:"code"`
+				Error entity.APIError  `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			if tt.wantContentType == httputil.ContentTypeProblemJSON {
+				assert.Equal(t, tt.wantCode, body.Code)
+			} else {
+				assert.Equal(t, tt.wantCode, body.Error.Code)
+			}
+		})
+	}
+}
+
+func TestMiddleware_AbortHandler(t *testing.T) {
+	handler := Middleware()(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -3,10 +3,15 @@ package http
 import (
 	"net/http"
 
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/config"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/handlers/health"
 	prdelivery "github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/handlers/pullRequest"
 	teamdelivery "github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/handlers/team"
 	userdelivery "github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/handlers/user"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/middleware/accesslog"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/middleware/httpmetrics"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/delivery/http/middleware/recovery"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/metrics"
 	prrepo "github.com/derletzte256/avito-assignment-2025-autumn/internal/repo/postgres/pullRequest"
 	teamrepo "github.com/derletzte256/avito-assignment-2025-autumn/internal/repo/postgres/team"
 	userrepo "github.com/derletzte256/avito-assignment-2025-autumn/internal/repo/postgres/user"
@@ -15,32 +20,53 @@ import (
 	userusecase "github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase/user"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
-	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
-func NewRouter(pool *pgxpool.Pool, trManager *manager.Manager) http.Handler {
-	r := mux.NewRouter()
-
-	r.Use(accesslog.Middleware())
+// UseCases is the business layer shared by the HTTP handlers and background
+// workers.
+type UseCases struct {
+	Team        *teamusecase.UseCase
+	User        *userusecase.UseCase
+	PullRequest *prusecase.UseCase
+}
 
+func NewUseCases(pool *pgxpool.Pool, trManager trm.Manager, m *metrics.Metrics, reviewCfg config.ReviewConfig) *UseCases {
 	teamRepo := teamrepo.NewTeamRepo(pool, trmpgx.DefaultCtxGetter)
 	userRepo := userrepo.NewUserRepo(pool, trmpgx.DefaultCtxGetter)
 	pullRequestRepo := prrepo.NewRepo(pool, trmpgx.DefaultCtxGetter)
 
-	teamUC := teamusecase.NewUseCase(teamRepo, userRepo, trManager)
-	teamDelivery := teamdelivery.NewTeamDelivery(teamUC)
+	m.MustRegister(metrics.NewDomainCollector(pullRequestRepo))
+
+	return &UseCases{
+		Team:        teamusecase.NewUseCase(teamRepo, userRepo, pullRequestRepo, trManager),
+		User:        userusecase.NewUseCase(userRepo, pullRequestRepo, teamRepo, trManager),
+		PullRequest: prusecase.NewUseCase(pullRequestRepo, userRepo, teamRepo, trManager, reviewCfg.FallbackDepth),
+	}
+}
+
+func NewRouter(uc *UseCases, m *metrics.Metrics, serviceName string, healthDelivery *health.Delivery) http.Handler {
+	root := mux.NewRouter()
 
-	userUC := userusecase.NewUseCase(userRepo, pullRequestRepo, teamRepo, trManager)
-	userDelivery := userdelivery.NewUserDelivery(userUC)
+	// Probes and scrapes are served outside the API middleware chain so they
+	// do not flood the access log and request metrics.
+	healthDelivery.RegisterRoutes(root)
+	root.Handle("/metrics", m.Handler()).Methods(http.MethodGet)
 
-	pullRequestUC := prusecase.NewUseCase(pullRequestRepo, userRepo, teamRepo, trManager)
-	pullRequestDelivery := prdelivery.NewDelivery(pullRequestUC)
+	r := mux.NewRouter()
+	root.PathPrefix("/").Handler(r)
+
+	r.Use(otelmux.Middleware(serviceName))
+	r.Use(accesslog.Middleware())
+	r.Use(httpmetrics.Middleware(m))
+	r.Use(recovery.Middleware())
 
-	teamDelivery.RegisterRoutes(r)
-	userDelivery.RegisterRoutes(r)
-	pullRequestDelivery.RegisterRoutes(r)
+	teamdelivery.NewTeamDelivery(uc.Team).RegisterRoutes(r)
+	userdelivery.NewUserDelivery(uc.User).RegisterRoutes(r)
+	prdelivery.NewDelivery(uc.PullRequest).RegisterRoutes(r)
 
-	return r
+	return root
 }
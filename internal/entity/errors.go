@@ -1,13 +1,69 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
+
+// Error is a domain error that carries the API code and HTTP status it is
+// reported with, so handlers do not have to map errors themselves.
+type Error struct {
+	Code    ErrorCode
+	Status  int
+	Message string
+	cause   error
+}
+
+func NewError(code ErrorCode, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Wrapping returns a copy of e that also matches cause with errors.Is.
+func (e *Error) Wrapping(cause error) *Error {
+	c := *e
+	c.cause = cause
+	return &c
+}
+
+// ErrAlreadyExists is reported by repositories on unique violations. Use cases
+// translate it into ErrTeamExists or ErrPRExists, which keep matching it.
+var ErrAlreadyExists = errors.New("already exists")
 
 var (
-	ErrNotFound            = errors.New("resource not found")
-	ErrAlreadyExists       = errors.New("already exists")
-	ErrPRMerged            = errors.New("pull request already merged")
-	ErrNotAssignedReviewer = errors.New("user is not an assigned reviewer")
-	ErrNoCandidate         = errors.New("no candidate reviewers available")
-	ErrDuplicateUserIDs    = errors.New("duplicate user IDs provided")
-	ErrUsersNotInSameTeam  = errors.New("users are not in the same team")
+	ErrNotFound            = NewError(ErrorCodeNotFound, http.StatusNotFound, "resource not found")
+	ErrTeamNotFound        = NewError(ErrorCodeNotFound, http.StatusNotFound, "team not found").Wrapping(ErrNotFound)
+	ErrUserNotFound        = NewError(ErrorCodeNotFound, http.StatusNotFound, "user_id not found").Wrapping(ErrNotFound)
+	ErrAbsenceNotFound     = NewError(ErrorCodeNotFound, http.StatusNotFound, "unavailability entry not found").Wrapping(ErrNotFound)
+	ErrTeamExists          = NewError(ErrorCodeTeamExists, http.StatusConflict, "team_name already exists").Wrapping(ErrAlreadyExists)
+	ErrUserExists          = NewError(ErrorCodeUserExists, http.StatusConflict, "user_id already exists").Wrapping(ErrAlreadyExists)
+	ErrTeamArchived        = NewError(ErrorCodeTeamArchived, http.StatusConflict, "team is archived")
+	ErrPRExists            = NewError(ErrorCodePRExists, http.StatusBadRequest, "PR id already exists").Wrapping(ErrAlreadyExists)
+	ErrPRMerged            = NewError(ErrorCodePRMerged, http.StatusBadRequest, "cannot reassign on merged PR")
+	ErrNotAssignedReviewer = NewError(ErrorCodeNotAssigned, http.StatusBadRequest, "reviewer is not assigned to this PR")
+	ErrNoCandidate         = NewError(ErrorCodeNoCandidate, http.StatusConflict, "no active replacement candidate in team")
+	ErrDuplicateUserIDs    = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "duplicate user IDs in request")
+	ErrUsersNotInSameTeam  = NewError(ErrorCodeNotSameTeam, http.StatusBadRequest, "deactivated users should be from the same team")
+	ErrNotTeamMember       = NewError(ErrorCodeNotTeamMember, http.StatusBadRequest, "users are not members of the team")
+	ErrTeamCycle           = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "team cannot be a descendant of itself")
+	ErrInvalidTeamSettings = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "approval_quorum cannot exceed reviewer_count")
+	ErrSettingsChanged     = NewError(ErrorCodePreconditionFailed, http.StatusPreconditionFailed, "team settings were changed, reload them and retry")
+	ErrIfMatchRequired     = NewError(ErrorCodePreconditionRequired, http.StatusPreconditionRequired, "If-Match header with the settings version is required")
+	ErrReviewerChoiceOff   = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "team does not allow authors to choose reviewers")
+	ErrTooManyReviewers    = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "more reviewers than the team reviewer_count")
+	ErrInvalidReviewers    = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "reviewers must be active members of the author's team")
+	ErrPlanNotFound        = NewError(ErrorCodeNotFound, http.StatusNotFound, "plan_token not found or expired").Wrapping(ErrNotFound)
+	ErrPlanMismatch        = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "plan_token was issued for another team or users")
+	ErrNoPrimaryTeam       = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "users without a primary team can only be deactivated with team_name")
+	ErrInactiveTarget      = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "to_user_id must be an active user")
+	ErrUnavailableTarget   = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "to_user_id is away right now")
+	ErrNoTeamForScope      = NewError(ErrorCodeInvalidInput, http.StatusBadRequest, "scope team needs from_user_id to have a primary team")
+	ErrPlanOutdated        = NewError(ErrorCodePlanOutdated, http.StatusConflict, "reviews changed since the plan was previewed, preview it again")
 )
@@ -7,6 +7,25 @@ const (
 	StatusMerged = "MERGED"
 )
 
+const (
+	HandoverScopeAll  = "all"
+	HandoverScopeTeam = "team"
+)
+
+// Reasons a review is skipped by a handover.
+const (
+	SkipReasonAuthor   = "author"
+	SkipReasonReviewer = "already_reviewer"
+	SkipReasonCap      = "review_cap"
+)
+
+// Events that mark reviewer changes made by bulk operations in the review
+// audit log.
+const (
+	AuditEventRebalance = "team_rebalance"
+	AuditEventHandover  = "reviews_handover"
+)
+
 type PullRequest struct {
 	ID        string     `json:"pull_request_id"`
 	Name      string     `json:"pull_request_name"`
@@ -14,12 +33,18 @@ type PullRequest struct {
 	Status    string     `json:"status"`
 	Reviewers []string   `json:"assigned_reviewers,omitempty"`
 	MergedAt  *time.Time `json:"merged_at,omitempty"`
+	// CrossTeamReviewers lists assigned reviewers borrowed from outside the author's team.
+	CrossTeamReviewers []string `json:"cross_team_reviewers,omitempty"`
+	// AwaitingReviewers is how many reviewers the PR still waits for in the
+	// backlog of under-staffed PRs.
+	AwaitingReviewers int `json:"awaiting_reviewers,omitempty"`
 }
 
 type ReviewReassignment struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldReviewerID string `json:"old_reviewer_id"`
 	NewReviewerID string `json:"new_reviewer_id"`
+	Escalated     bool   `json:"escalated,omitempty"`
 }
 
 type UnreassignedReview struct {
@@ -27,7 +52,33 @@ type UnreassignedReview struct {
 	ReviewerID    string `json:"reviewer_id"`
 }
 
+// SkippedReview is an open review a handover left with its reviewer.
+type SkippedReview struct {
+	PullRequestID string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
 type ReviewRecord struct {
 	PullRequestID string
 	ReviewerID    string
 }
+
+// BacklogEntry is an open PR waiting for reviewers in the backlog.
+type BacklogEntry struct {
+	PullRequestID     string    `json:"pull_request_id"`
+	PullRequestName   string    `json:"pull_request_name"`
+	AuthorID          string    `json:"author_id"`
+	TeamName          string    `json:"team_name,omitempty"`
+	AssignedReviewers []string  `json:"assigned_reviewers"`
+	MissingReviewers  int       `json:"missing_reviewers"`
+	QueuedAt          time.Time `json:"queued_at"`
+}
+
+// BacklogCandidates are the users that can fill a backlog PR, along with its
+// departed reviewers: those inactive or away right now.
+type BacklogCandidates struct {
+	PullRequestID       string
+	MissingReviewers    int
+	DepartedReviewerIDs []string
+	CandidateIDs        []string
+}
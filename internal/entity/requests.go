@@ -1,16 +1,41 @@
 package entity
 
+import "time"
+
 type CreateTeamRequest = Team
 
 type CreatePullRequestRequest struct {
 	PullRequestID string `json:"pull_request_id" validate:"required,min=1,max=64"`
 	Name          string `json:"pull_request_name" validate:"required,min=1,max=128"`
 	AuthorID      string `json:"author_id" validate:"required,min=1,max=64"`
+	// ReviewerIDs are reviewers chosen by the author, allowed only if the team
+	// settings permit it. Remaining slots are filled automatically.
+	ReviewerIDs []string `json:"reviewer_ids,omitempty" validate:"omitempty,max=5,unique,dive,required,min=1,max=64"`
 }
 
 type SetUserActiveRequest struct {
 	UserID   string `json:"user_id" validate:"required,min=1,max=64"`
 	IsActive *bool  `json:"is_active" validate:"required"`
+	// TargetOpenReviews makes a reactivated user take open reviews over from
+	// the most loaded teammates until they have this many.
+	TargetOpenReviews int `json:"target_open_reviews,omitempty" validate:"excluded_if=IsActive false,min=0,max=100"`
+	// ReassignOpenReviews hands open reviews of a deactivated user over to
+	// their teammates, as MassDeactivateUsers does.
+	ReassignOpenReviews bool `json:"reassign_open_reviews,omitempty" validate:"excluded_if=IsActive true"`
+}
+
+type ChangeUserTeamRequest struct {
+	UserID   string `json:"user_id" validate:"required,min=1,max=64"`
+	TeamName string `json:"team_name" validate:"required,min=1,max=128"`
+}
+
+// HandoverReviewsRequest moves open reviews of FromUserID to ToUserID. Scope
+// is all (default) or team, which limits the handover to PRs authored by
+// members of the primary team of FromUserID.
+type HandoverReviewsRequest struct {
+	FromUserID string `json:"from_user_id" validate:"required,min=1,max=64"`
+	ToUserID   string `json:"to_user_id" validate:"required,min=1,max=64,nefield=FromUserID"`
+	Scope      string `json:"scope,omitempty" validate:"omitempty,oneof=all team"`
 }
 
 type MergePullRequestRequest struct {
@@ -22,7 +47,120 @@ type ReassignPullRequestRequest struct {
 	OldUserID     string `json:"old_reviewer_id" validate:"required,min=1,max=64"`
 }
 
+// MassDeactivateUsersRequest without TeamName groups users by their primary
+// teams. With DryRun it only previews the plan and returns a token for it; a
+// later request with PlanToken applies exactly that plan.
 type MassDeactivateUsersRequest struct {
+	TeamName  string   `json:"team_name,omitempty" validate:"omitempty,min=1,max=128"`
+	UserIDs   []string `json:"user_ids" validate:"required,min=1,max=100,dive,required"`
+	DryRun    bool     `json:"dry_run"`
+	PlanToken string   `json:"plan_token,omitempty" validate:"omitempty,len=32,hexadecimal,excluded_with=DryRun"`
+}
+
+type AddTeamMembersRequest struct {
+	TeamName string    `json:"team_name" validate:"required,min=1,max=128"`
+	Members  []*Member `json:"members" validate:"required,min=1,max=100,dive,required"`
+	// Secondary adds existing users to the team without moving them from their primary team.
+	Secondary bool `json:"secondary"`
+}
+
+type RemoveTeamMembersRequest struct {
 	TeamName string   `json:"team_name" validate:"required,min=1,max=128"`
 	UserIDs  []string `json:"user_ids" validate:"required,min=1,max=100,dive,required"`
 }
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name" validate:"required,min=1,max=128"`
+	NewTeamName string `json:"new_team_name" validate:"required,min=1,max=128,nefield=TeamName"`
+}
+
+type ArchiveTeamRequest struct {
+	TeamName string `json:"team_name" validate:"required,min=1,max=128"`
+}
+
+type SetTeamParentRequest struct {
+	TeamName       string `json:"team_name" validate:"required,min=1,max=128"`
+	ParentTeamName string `json:"parent_team_name" validate:"omitempty,max=128,nefield=TeamName"`
+}
+
+type SetLeadEscalationRequest struct {
+	TeamName string `json:"team_name" validate:"required,min=1,max=128"`
+	Enabled  *bool  `json:"enabled" validate:"required"`
+}
+
+// RebalanceTeamRequest moves open reviews between active members until their
+// open review counts are within Tolerance of the team mean. DryRun only
+// reports the moves.
+type RebalanceTeamRequest struct {
+	TeamName  string  `json:"team_name" validate:"required,min=1,max=128"`
+	Tolerance float64 `json:"tolerance" validate:"min=0,max=100"` // optional, 0 - as even as possible
+	DryRun    bool    `json:"dry_run"`
+}
+
+type UpdateTeamSettingsRequest struct {
+	TeamName               string `json:"team_name" validate:"required,min=1,max=128"`
+	ReviewerCount          *int   `json:"reviewer_count" validate:"required,min=0,max=5"`
+	SelectionStrategy      string `json:"selection_strategy" validate:"required,oneof=random least_loaded working_hours"`
+	ReviewSLAHours         *int   `json:"review_sla_hours" validate:"required,min=0,max=720"`
+	ApprovalQuorum         *int   `json:"approval_quorum" validate:"required,min=0,max=5"`
+	AuthorChoosesReviewers *bool  `json:"author_chooses_reviewers" validate:"required"`
+	CrossTeamFallback      *bool  `json:"cross_team_fallback" validate:"required"`
+	MaxOpenReviews         *int   `json:"max_open_reviews" validate:"required,min=0,max=100"` // 0 - no cap
+}
+
+type CreateUserRequest struct {
+	UserID   string `json:"user_id" validate:"required,min=1,max=64"`
+	Username string `json:"username" validate:"required,min=1,max=128"`
+	// IsActive defaults to true.
+	IsActive *bool `json:"is_active"`
+	// TeamName is the optional primary team of the user.
+	TeamName       string `json:"team_name,omitempty" validate:"omitempty,min=1,max=128"`
+	Email          string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	ChatHandle     string `json:"chat_handle,omitempty" validate:"omitempty,max=64"`
+	Timezone       string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Seniority      string `json:"seniority,omitempty" validate:"omitempty,oneof=junior middle senior staff principal"`
+	WorkHoursStart string `json:"work_hours_start,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkHoursEnd   string `json:"work_hours_end,omitempty" validate:"omitempty,datetime=15:04"`
+	// MaxOpenReviews overrides the team default, 0 keeps the default.
+	MaxOpenReviews int `json:"max_open_reviews,omitempty" validate:"min=0,max=100"`
+}
+
+// UpdateUserRequest changes only the fields that are set. An empty string
+// clears an optional profile field.
+type UpdateUserRequest struct {
+	UserID         string  `json:"user_id" validate:"required,min=1,max=64"`
+	Username       *string `json:"username" validate:"omitempty,min=1,max=128"`
+	Email          *string `json:"email" validate:"omitempty,max=254,email|len=0"`
+	ChatHandle     *string `json:"chat_handle" validate:"omitempty,max=64"`
+	Timezone       *string `json:"timezone" validate:"omitempty,timezone|len=0"`
+	Seniority      *string `json:"seniority" validate:"omitempty,oneof=junior middle senior staff principal|len=0"`
+	WorkHoursStart *string `json:"work_hours_start" validate:"omitempty,datetime=15:04|len=0"`
+	WorkHoursEnd   *string `json:"work_hours_end" validate:"omitempty,datetime=15:04|len=0"`
+	// MaxOpenReviews of 0 drops the personal cap.
+	MaxOpenReviews *int `json:"max_open_reviews" validate:"omitempty,min=0,max=100"`
+}
+
+// SearchUsersRequest is read from the query string of GET /users/search.
+type SearchUsersRequest struct {
+	Query  string `json:"query" validate:"required,min=1,max=128"`
+	Limit  int    `json:"limit" validate:"min=1,max=100"`
+	Offset int    `json:"offset" validate:"min=0"`
+}
+
+type DeleteUserRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1,max=64"`
+	// Erase also scrubs the username and profile fields.
+	Erase bool `json:"erase"`
+}
+
+type AddUnavailabilityRequest struct {
+	UserID   string    `json:"user_id" validate:"required,min=1,max=64"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	Reason   string    `json:"reason" validate:"max=255"`
+}
+
+type RemoveUnavailabilityRequest struct {
+	UserID string `json:"user_id" validate:"required,min=1,max=64"`
+	ID     int64  `json:"id" validate:"required,min=1"`
+}
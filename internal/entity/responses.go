@@ -1,20 +1,54 @@
 package entity
 
+import "time"
+
 type ErrorCode string
 
 const (
-	ErrorCodeTeamExists  ErrorCode = "TEAM_EXISTS"
-	ErrorCodePRExists    ErrorCode = "PR_EXISTS"
-	ErrorCodePRMerged    ErrorCode = "PR_MERGED"
-	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
-	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
-	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
-	ErrorCodeInternal    ErrorCode = "INTERNAL"
-
-	ErrorCodeInvalidInput ErrorCode = "INVALID_INPUT"
-	ErrorCodeNotSameTeam  ErrorCode = "NOT_SAME_TEAM"
+	ErrorCodeTeamExists   ErrorCode = "TEAM_EXISTS"
+	ErrorCodeUserExists   ErrorCode = "USER_EXISTS"
+	ErrorCodeTeamArchived ErrorCode = "TEAM_ARCHIVED"
+	ErrorCodePRExists     ErrorCode = "PR_EXISTS"
+	ErrorCodePRMerged     ErrorCode = "PR_MERGED"
+	ErrorCodeNotAssigned  ErrorCode = "NOT_ASSIGNED"
+	ErrorCodeNoCandidate  ErrorCode = "NO_CANDIDATE"
+	ErrorCodeNotFound     ErrorCode = "NOT_FOUND"
+	ErrorCodeInternal     ErrorCode = "INTERNAL"
+
+	ErrorCodeInvalidInput         ErrorCode = "INVALID_INPUT"
+	ErrorCodeNotSameTeam          ErrorCode = "NOT_SAME_TEAM"
+	ErrorCodeNotTeamMember        ErrorCode = "NOT_TEAM_MEMBER"
+	ErrorCodePreconditionFailed   ErrorCode = "PRECONDITION_FAILED"
+	ErrorCodePreconditionRequired ErrorCode = "PRECONDITION_REQUIRED"
+	ErrorCodePlanOutdated         ErrorCode = "PLAN_OUTDATED"
 )
 
+var problemTypes = map[ErrorCode]string{
+	ErrorCodeTeamExists:           "/problems/team-exists",
+	ErrorCodeUserExists:           "/problems/user-exists",
+	ErrorCodeTeamArchived:         "/problems/team-archived",
+	ErrorCodePRExists:             "/problems/pr-exists",
+	ErrorCodePRMerged:             "/problems/pr-merged",
+	ErrorCodeNotAssigned:          "/problems/not-assigned",
+	ErrorCodeNoCandidate:          "/problems/no-candidate",
+	ErrorCodeNotFound:             "/problems/not-found",
+	ErrorCodeInternal:             "/problems/internal",
+	ErrorCodeInvalidInput:         "/problems/invalid-input",
+	ErrorCodeNotSameTeam:          "/problems/not-same-team",
+	ErrorCodeNotTeamMember:        "/problems/not-team-member",
+	ErrorCodePreconditionFailed:   "/problems/precondition-failed",
+	ErrorCodePreconditionRequired: "/problems/precondition-required",
+	ErrorCodePlanOutdated:         "/problems/plan-outdated",
+}
+
+// ProblemType returns the RFC 7807 type URI for the code, or about:blank for unknown codes.
+func (c ErrorCode) ProblemType() string {
+	if uri, ok := problemTypes[c]; ok {
+		return uri
+	}
+	return "about:blank"
+}
+
 type APIError struct {
 	Code    ErrorCode `json:"code"`
 	Message string    `json:"message"`
@@ -25,6 +59,14 @@ type ErrorResponse struct {
 	Error APIError `json:"error"`
 }
 
+type AddTeamMembersResponse = CreateTeamResponse
+
+type RenameTeamResponse = CreateTeamResponse
+
+type SetTeamParentResponse = CreateTeamResponse
+
+type SetLeadEscalationResponse = CreateTeamResponse
+
 type CreateTeamResponse struct {
 	Team *Team `json:"team"`
 }
@@ -37,10 +79,31 @@ type CreatePullRequestResponse = PullRequestResponse
 
 type MergePullRequestResponse = PullRequestResponse
 
-type SetUserActiveResponse struct {
+type UserResponse struct {
 	User *User `json:"user"`
 }
 
+// SetUserActiveResponse reports reviews the user took over on reactivation or
+// handed over on deactivation, if the request asked for it.
+type SetUserActiveResponse struct {
+	User                *User                 `json:"user"`
+	ReviewReassignments []*ReviewReassignment `json:"review_reassignments,omitempty"`
+	UnreassignedReviews []*UnreassignedReview `json:"unreassigned_reviews,omitempty"`
+}
+
+type GetUserResponse = UserResponse
+
+type CreateUserResponse = UserResponse
+
+type UpdateUserResponse = UserResponse
+
+type SearchUsersResponse struct {
+	Users   []*User `json:"users"`
+	Limit   int     `json:"limit"`
+	Offset  int     `json:"offset"`
+	HasMore bool    `json:"has_more"`
+}
+
 type UserReviewListResponse struct {
 	UserID       string         `json:"user_id"`
 	PullRequests []*PullRequest `json:"pull_requests"`
@@ -49,14 +112,123 @@ type UserReviewListResponse struct {
 type ReassignPullRequestResponse struct {
 	*PullRequestResponse
 	ReplacedBy string `json:"replaced_by"`
+	// Escalated is set when nobody but a team lead could take the review.
+	Escalated bool `json:"escalated,omitempty"`
 }
 
 type StatsByUsersResponse struct {
 	UsersStat map[string]*UserStatistics `json:"users_stat"`
 }
 
+// MassDeactivateUsersResponse lists all moves of the deactivation. When users
+// were grouped by their primary teams, Teams breaks the moves down by team and
+// TeamName is empty.
 type MassDeactivateUsersResponse struct {
+	TeamName            string                `json:"team_name,omitempty"`
+	ReviewReassignments []*ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []*UnreassignedReview `json:"unreassigned_reviews"`
+	Teams               []*TeamDeactivation   `json:"teams,omitempty"`
+	DryRun              bool                  `json:"dry_run,omitempty"`
+	PlanToken           string                `json:"plan_token,omitempty"`      // set on dry_run, applies exactly this plan
+	PlanExpiresAt       *time.Time            `json:"plan_expires_at,omitempty"` // set on dry_run
+}
+
+// TeamDeactivation is the part of a mass deactivation that falls on one team:
+// its users and the moves of their reviews to the team's members.
+type TeamDeactivation struct {
 	TeamName            string                `json:"team_name"`
+	UserIDs             []string              `json:"user_ids"`
 	ReviewReassignments []*ReviewReassignment `json:"review_reassignments"`
 	UnreassignedReviews []*UnreassignedReview `json:"unreassigned_reviews"`
 }
+
+type RemoveTeamMembersResponse = MassDeactivateUsersResponse
+
+// ArchiveTeamResponse lists open PRs authored by members of the archived team;
+// they keep their reviewers but need a new owner to be driven to merge.
+type ArchiveTeamResponse struct {
+	TeamName         string         `json:"team_name"`
+	ArchivedAt       time.Time      `json:"archived_at"`
+	OpenPullRequests []*PullRequest `json:"open_pull_requests"`
+}
+
+// RebalanceTeamResponse reports the moves of a team rebalancing and the open
+// review counts of active members once they are applied.
+type RebalanceTeamResponse struct {
+	TeamName            string                `json:"team_name"`
+	DryRun              bool                  `json:"dry_run"`
+	MeanOpenReviews     float64               `json:"mean_open_reviews"`
+	ReviewReassignments []*ReviewReassignment `json:"review_reassignments"`
+	OpenReviews         map[string]int        `json:"open_reviews"`
+}
+
+// HandoverReviewsResponse reports every open review of the handover: moved
+// ones and ones skipped because the target authored or already reviews the PR.
+type HandoverReviewsResponse struct {
+	FromUserID string                `json:"from_user_id"`
+	ToUserID   string                `json:"to_user_id"`
+	Scope      string                `json:"scope"`
+	Moved      []*ReviewReassignment `json:"moved"`
+	Skipped    []*SkippedReview      `json:"skipped"`
+}
+
+type ChangeUserTeamResponse struct {
+	User                *User                 `json:"user"`
+	OldTeamName         string                `json:"old_team_name"`
+	ReviewReassignments []*ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []*UnreassignedReview `json:"unreassigned_reviews"`
+}
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// DeleteUserResponse reports the pseudonymous ID that replaced the user ID on
+// their PRs and reviews, and what happened to their open reviews.
+type DeleteUserResponse struct {
+	UserID              string                `json:"user_id"`
+	PseudonymID         string                `json:"pseudonym_id"`
+	Erased              bool                  `json:"erased"`
+	DeletedAt           time.Time             `json:"deleted_at"`
+	ReviewReassignments []*ReviewReassignment `json:"review_reassignments"`
+	UnreassignedReviews []*UnreassignedReview `json:"unreassigned_reviews"`
+}
+
+type AddUnavailabilityResponse struct {
+	Unavailability *Unavailability `json:"unavailability"`
+}
+
+// BacklogResponse lists PRs waiting for reviewers, oldest first.
+type BacklogResponse struct {
+	PullRequests []*BacklogEntry `json:"pull_requests"`
+}
+
+// UserUnavailabilityResponse lists absences of the user ordered by start.
+type UserUnavailabilityResponse struct {
+	UserID         string            `json:"user_id"`
+	Unavailability []*Unavailability `json:"unavailability"`
+}
+
+type HealthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// InvalidParam describes a single failed validation rule of a request field.
+type InvalidParam struct {
+	Name   string `json:"name"`   // JSON path of the field, e.g. members[0].user_id
+	Reason string `json:"reason"` // validation rule that failed, e.g. required or max
+	Param  string `json:"param,omitempty"`
+}
+
+// ProblemDetails is an RFC 7807 application/problem+json error body.
+type ProblemDetails struct {
+	Type          string          `json:"type"`
+	Title         string          `json:"title"`
+	Status        int             `json:"status"`
+	Detail        string          `json:"detail,omitempty"`
+	Instance      string          `json:"instance,omitempty"`
+	Code          ErrorCode       `json:"code"`
+	InvalidParams []*InvalidParam `json:"invalid_params,omitempty"`
+}
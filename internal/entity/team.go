@@ -1,12 +1,28 @@
 package entity
 
+const (
+	RoleMember = "member"
+	RoleLead   = "lead"
+)
+
 type Team struct {
-	Name    string    `json:"team_name" validate:"required,min=1,max=128"`
-	Members []*Member `json:"members" validate:"required,dive"`
+	Name       string    `json:"team_name" validate:"required,min=1,max=128"`
+	ParentName string    `json:"parent_team_name,omitempty" validate:"omitempty,max=128,nefield=Name"`
+	Members    []*Member `json:"members" validate:"required,dive"`
+	// EscalateToLead hands reviews nobody else in the team can take over to its leads.
+	EscalateToLead bool `json:"escalate_to_lead,omitempty"`
 }
 
 type Member struct {
 	ID       string `json:"user_id" validate:"required,min=1,max=64"`
 	Username string `json:"username" validate:"required,min=1,max=128"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=member lead"`
+	// Profile fields are kept as stored when omitted for an existing user.
+	Email          string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	ChatHandle     string `json:"chat_handle,omitempty" validate:"omitempty,max=64"`
+	Timezone       string `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	Seniority      string `json:"seniority,omitempty" validate:"omitempty,oneof=junior middle senior staff principal"`
+	WorkHoursStart string `json:"work_hours_start,omitempty" validate:"omitempty,datetime=15:04"`
+	WorkHoursEnd   string `json:"work_hours_end,omitempty" validate:"omitempty,datetime=15:04"`
 }
@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+const (
+	StrategyRandom      = "random"
+	StrategyLeastLoaded = "least_loaded"
+	// StrategyWorkingHours prefers reviewers that are within their working
+	// hours now, then those whose working day starts soonest.
+	StrategyWorkingHours = "working_hours"
+)
+
+// TeamSettings configures reviewer assignment for a team. Version is bumped
+// on every update and is exposed as the ETag of the settings resource.
+type TeamSettings struct {
+	TeamName               string    `json:"team_name"`
+	ReviewerCount          int       `json:"reviewer_count"`
+	SelectionStrategy      string    `json:"selection_strategy"`
+	ReviewSLAHours         int       `json:"review_sla_hours"`
+	ApprovalQuorum         int       `json:"approval_quorum"`
+	AuthorChoosesReviewers bool      `json:"author_chooses_reviewers"`
+	CrossTeamFallback      bool      `json:"cross_team_fallback"`
+	MaxOpenReviews         int       `json:"max_open_reviews"` // applies to members without a cap of their own, 0 - no cap
+	Version                int       `json:"version"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// DefaultTeamSettings mirrors the column defaults of team_settings. It is used
+// for users that are not in any team.
+func DefaultTeamSettings(teamName string) *TeamSettings {
+	return &TeamSettings{
+		TeamName:          teamName,
+		ReviewerCount:     2,
+		SelectionStrategy: StrategyRandom,
+		ApprovalQuorum:    1,
+		CrossTeamFallback: true,
+	}
+}
@@ -1,10 +1,33 @@
 package entity
 
+import "time"
+
+const (
+	SeniorityJunior    = "junior"
+	SeniorityMiddle    = "middle"
+	SenioritySenior    = "senior"
+	SeniorityStaff     = "staff"
+	SeniorityPrincipal = "principal"
+)
+
 type User struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name,omitempty"`
+	ID         string `json:"user_id"`
+	Username   string `json:"username"`
+	IsActive   bool   `json:"is_active"`
+	TeamName   string `json:"team_name,omitempty"`
+	Email      string `json:"email,omitempty"`
+	ChatHandle string `json:"chat_handle,omitempty"`
+	// Timezone is an IANA name such as "Europe/Moscow".
+	Timezone  string `json:"timezone,omitempty"`
+	Seniority string `json:"seniority,omitempty"`
+	// WorkHoursStart and WorkHoursEnd are "HH:MM" in the user's timezone.
+	WorkHoursStart string `json:"work_hours_start,omitempty"`
+	WorkHoursEnd   string `json:"work_hours_end,omitempty"`
+	// MaxOpenReviews caps open reviews of the user; nil falls back to the
+	// default of their primary team.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// DeletedAt is set for deleted users, whose ID is then a pseudonym.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 type UserStatistics struct {
@@ -13,3 +36,30 @@ type UserStatistics struct {
 	OnReviewPRCount int    `json:"on_review_pr_count"` // Where is reviewer and PR is open
 	ReviewedPRCount int    `json:"reviewed_pr_count"`  // Where is reviewer and PR is merged
 }
+
+// Unavailability is a period when the user must not be picked as a reviewer,
+// e.g. a vacation or sick leave.
+type Unavailability struct {
+	ID       int64     `json:"id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+	// ReviewsReassignedAt is set once open reviews of the user were handed
+	// over because of this absence.
+	ReviewsReassignedAt *time.Time `json:"reviews_reassigned_at,omitempty"`
+}
+
+// DeactivationPlan is a previewed mass deactivation kept until it is applied
+// by its token or expires. Fingerprint identifies the reviews it was built
+// from. TeamName is empty and Teams is set when users were grouped by team.
+type DeactivationPlan struct {
+	Token         string
+	TeamName      string
+	UserIDs       []string
+	Fingerprint   string
+	Reassignments []*ReviewReassignment
+	Unreassigned  []*UnreassignedReview
+	Teams         []*TeamDeactivation
+	ExpiresAt     time.Time
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+// MigrationsHeadVersion returns the latest migration version embedded into the binary.
+func MigrationsHeadVersion() (int64, error) {
+	migrations, err := goose.CollectMigrations(".", 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("collect goose migrations: %w", err)
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, fmt.Errorf("get last goose migration: %w", err)
+	}
+
+	return last.Version, nil
+}
+
+// NewMigrationsCheck returns a readiness check that fails until the database
+// schema is at the embedded head version.
+func NewMigrationsCheck(pool *pgxpool.Pool) (func(ctx context.Context) error, error) {
+	head, err := MigrationsHeadVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB := stdlib.OpenDBFromPool(pool)
+
+	return func(ctx context.Context) error {
+		current, err := goose.GetDBVersionContext(ctx, sqlDB)
+		if err != nil {
+			return fmt.Errorf("get goose db version: %w", err)
+		}
+		if current != head {
+			return fmt.Errorf("database is at migration %d, expected %d", current, head)
+		}
+		return nil
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/config"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -42,13 +43,23 @@ func RunMigrations(ctx context.Context, cfg config.DatabaseConfig) error {
 	return nil
 }
 
-func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
-	connString := ConnString(cfg)
-
-	poolCfg, err := pgxpool.ParseConfig(connString)
+// newPoolConfig parses the pool config and traces queries if tracing is on.
+func newPoolConfig(cfg config.DatabaseConfig) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(ConnString(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("parse pg config: %w", err)
 	}
+	if tracing.Enabled() {
+		poolCfg.ConnConfig.Tracer = tracing.NewQueryTracer()
+	}
+	return poolCfg, nil
+}
+
+func NewPostgresPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := newPoolConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	p, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
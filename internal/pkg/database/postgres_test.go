@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/config"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPoolConfig_Tracer(t *testing.T) {
+	cfg := config.DatabaseConfig{Username: "app", Password: "secret", Host: "localhost", Port: 5432, Name: "app"}
+
+	poolCfg, err := newPoolConfig(cfg)
+	require.NoError(t, err)
+	assert.Nil(t, poolCfg.ConnConfig.Tracer, "queries must not be traced while tracing is disabled")
+
+	shutdown, err := tracing.Init(context.Background(), config.TracingConfig{Exporter: tracing.ExporterStdout, ServiceName: "test"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = shutdown(context.Background()) })
+
+	poolCfg, err = newPoolConfig(cfg)
+	require.NoError(t, err)
+	assert.IsType(t, &tracing.QueryTracer{}, poolCfg.ConnConfig.Tracer)
+}
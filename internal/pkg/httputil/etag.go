@@ -0,0 +1,33 @@
+package httputil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SetETag exposes version as a strong entity tag of the response.
+func SetETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// IfMatchVersion returns the version from an If-Match header written by
+// SetETag. ok is false when the header is absent. Tags that are not a version
+// yield 0, which never matches a stored version.
+func IfMatchVersion(r *http.Request) (version int, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return 0, false
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return 0, true
+	}
+	version, err = strconv.Atoi(unquoted)
+	if err != nil {
+		return 0, true
+	}
+	return version, true
+}
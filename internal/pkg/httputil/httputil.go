@@ -3,53 +3,116 @@ package httputil
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 )
 
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeProblemJSON = "application/problem+json"
+)
+
 func WriteJSON(w http.ResponseWriter, status int, payload interface{}) error {
+	return writeJSONWithContentType(w, status, ContentTypeJSON, payload)
+}
+
+func writeJSONWithContentType(w http.ResponseWriter, status int, contentType string, payload interface{}) error {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(payload); err != nil {
 		return err
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Type", contentType)
 	w.WriteHeader(status)
 	_, err := w.Write(buf.Bytes())
 	return err
 }
 
-func WriteAPIError(w http.ResponseWriter, status int, code entity.ErrorCode, message string, info ...string) error {
-	apiErr := entity.APIError{
-		Code:    code,
-		Message: message,
-	}
-
+// WriteAPIError writes the legacy ErrorResponse body, or an RFC 7807 problem
+// when the client asks for application/problem+json in Accept.
+func WriteAPIError(w http.ResponseWriter, r *http.Request, status int, code entity.ErrorCode, message string, info ...string) error {
+	var detail string
 	if len(info) > 0 {
-		apiErr.Info = info[0]
+		detail = info[0]
 	}
 
-	if writeErr := WriteJSON(w, status, entity.ErrorResponse{Error: apiErr}); writeErr != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return writeErr
+	return writeError(w, r, status, code, message, detail, nil)
+}
+
+// WriteError is the single place where use-case errors become HTTP responses.
+// Typed domain errors are written with their own code and status; anything
+// else is reported as INTERNAL.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) error {
+	var domainErr *entity.Error
+	if errors.As(err, &domainErr) {
+		return WriteAPIError(w, r, domainErr.Status, domainErr.Code, domainErr.Message)
 	}
 
+	WriteInternalServerError(w, r, err)
 	return nil
 }
 
-func WriteInternalServerError(w http.ResponseWriter, err error) {
+func WriteInternalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	var info string
 	if err != nil {
 		info = err.Error()
 	}
 
-	if writeErr := WriteAPIError(w, http.StatusInternalServerError, entity.ErrorCodeInternal, "internal server error", info); writeErr != nil {
+	if writeErr := WriteAPIError(w, r, http.StatusInternalServerError, entity.ErrorCodeInternal, "internal server error", info); writeErr != nil {
 		return
 	}
 }
 
+func writeError(
+	w http.ResponseWriter,
+	r *http.Request,
+	status int,
+	code entity.ErrorCode,
+	message, detail string,
+	invalidParams []*entity.InvalidParam,
+) error {
+	var writeErr error
+	if WantsProblemJSON(r) {
+		problem := entity.ProblemDetails{
+			Type:          code.ProblemType(),
+			Title:         message,
+			Status:        status,
+			Detail:        detail,
+			Instance:      r.URL.Path,
+			Code:          code,
+			InvalidParams: invalidParams,
+		}
+		writeErr = writeJSONWithContentType(w, status, ContentTypeProblemJSON, problem)
+	} else {
+		apiErr := entity.APIError{
+			Code:    code,
+			Message: message,
+			Info:    detail,
+		}
+		writeErr = WriteJSON(w, status, entity.ErrorResponse{Error: apiErr})
+	}
+
+	if writeErr != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return writeErr
+	}
+
+	return nil
+}
+
 func ReadJSON(r *http.Request, dst interface{}) error {
 	decoder := json.NewDecoder(r.Body)
 	return decoder.Decode(dst)
 }
+
+// QueryInt reads an integer query parameter, returning def when it is absent.
+func QueryInt(r *http.Request, name string, def int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	return strconv.Atoi(raw)
+}
@@ -0,0 +1,99 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    entity.ErrorCode
+		wantMessage string
+	}{
+		{
+			name:        "not found",
+			err:         entity.ErrUserNotFound,
+			wantStatus:  http.StatusNotFound,
+			wantCode:    entity.ErrorCodeNotFound,
+			wantMessage: "user_id not found",
+		},
+		{
+			name:        "not same team is a client error",
+			err:         entity.ErrUsersNotInSameTeam,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    entity.ErrorCodeNotSameTeam,
+			wantMessage: "deactivated users should be from the same team",
+		},
+		{
+			name:        "wrapped domain error",
+			err:         fmt.Errorf("create team: %w", entity.ErrTeamExists),
+			wantStatus:  http.StatusConflict,
+			wantCode:    entity.ErrorCodeTeamExists,
+			wantMessage: "team_name already exists",
+		},
+		{
+			name:        "precondition required",
+			err:         entity.ErrIfMatchRequired,
+			wantStatus:  http.StatusPreconditionRequired,
+			wantCode:    entity.ErrorCodePreconditionRequired,
+			wantMessage: "If-Match header with the settings version is required",
+		},
+		{
+			name:        "untyped error",
+			err:         errors.New("connection reset"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    entity.ErrorCodeInternal,
+			wantMessage: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/users/massDeactivate", nil)
+			w := httptest.NewRecorder()
+
+			require.NoError(t, WriteError(w, r, tt.err))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, ContentTypeJSON, w.Header().Get("Content-Type"))
+
+			var resp entity.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+			assert.Equal(t, tt.wantMessage, resp.Error.Message)
+		})
+	}
+}
+
+func TestWriteError_Problem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", nil)
+	r.Header.Set("Accept", ContentTypeProblemJSON)
+	w := httptest.NewRecorder()
+
+	require.NoError(t, WriteError(w, r, entity.ErrPRMerged))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, ContentTypeProblemJSON, w.Header().Get("Content-Type"))
+
+	var problem entity.ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, entity.ProblemDetails{
+		Type:     "/problems/pr-merged",
+		Title:    "cannot reassign on merged PR",
+		Status:   http.StatusBadRequest,
+		Instance: "/pullRequest/merge",
+		Code:     entity.ErrorCodePRMerged,
+	}, problem)
+}
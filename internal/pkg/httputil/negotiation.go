@@ -0,0 +1,49 @@
+package httputil
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WantsProblemJSON reports whether the Accept header prefers
+// application/problem+json over application/json. Clients that send no
+// Accept header or only */* keep getting the legacy error shape.
+func WantsProblemJSON(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+
+	problemQ, jsonQ := -1.0, -1.0
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, q := parseMediaRange(part)
+			switch mediaType {
+			case ContentTypeProblemJSON:
+				problemQ = max(problemQ, q)
+			case ContentTypeJSON:
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+func parseMediaRange(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+	q := 1.0
+	for _, param := range params[1:] {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+			q = parsed
+		}
+	}
+
+	return mediaType, q
+}
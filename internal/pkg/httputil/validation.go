@@ -0,0 +1,69 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// Report field names as they appear in the JSON payload instead of Go struct names.
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+func Validate(in interface{}) error {
+	return validate.Struct(in)
+}
+
+// WriteValidationError writes validator errors as INVALID_INPUT. Problem
+// responses list every invalid field with its JSON path, rule and parameter.
+func WriteValidationError(w http.ResponseWriter, r *http.Request, err error) error {
+	var errValid validator.ValidationErrors
+	if !errors.As(err, &errValid) {
+		WriteInternalServerError(w, r, err)
+		return nil
+	}
+
+	if !WantsProblemJSON(r) {
+		return WriteAPIError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "validation error: "+errValid.Error())
+	}
+
+	invalidParams := make([]*entity.InvalidParam, 0, len(errValid))
+	for _, fieldErr := range errValid {
+		invalidParams = append(invalidParams, &entity.InvalidParam{
+			Name:   jsonPath(fieldErr.Namespace()),
+			Reason: fieldErr.Tag(),
+			Param:  fieldErr.Param(),
+		})
+	}
+
+	detail := fmt.Sprintf("%d field(s) failed validation", len(invalidParams))
+	return writeError(w, r, http.StatusBadRequest, entity.ErrorCodeInvalidInput, "validation error", detail, invalidParams)
+}
+
+// jsonPath drops the top-level struct name from a validator namespace,
+// e.g. "Team.members[0].user_id" becomes "members[0].user_id".
+func jsonPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
@@ -0,0 +1,122 @@
+package httputil
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validationMember struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type validationTeam struct {
+	TeamName string              `json:"team_name" validate:"required,max=8"`
+	Members  []*validationMember `json:"members" validate:"dive"`
+}
+
+func TestWriteValidationError(t *testing.T) {
+	invalid := validationTeam{
+		TeamName: "too-long-name",
+		Members:  []*validationMember{{UserID: "u1"}, {}},
+	}
+
+	tests := []struct {
+		name            string
+		accept          string
+		err             error
+		wantStatus      int
+		wantContentType string
+		wantCode        entity.ErrorCode
+		wantParams      []*entity.InvalidParam
+	}{
+		{
+			name:            "legacy body",
+			err:             Validate(invalid),
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: ContentTypeJSON,
+			wantCode:        entity.ErrorCodeInvalidInput,
+		},
+		{
+			name:            "problem with invalid params",
+			accept:          ContentTypeProblemJSON,
+			err:             Validate(invalid),
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: ContentTypeProblemJSON,
+			wantCode:        entity.ErrorCodeInvalidInput,
+			wantParams: []*entity.InvalidParam{
+				{Name: "team_name", Reason: "max", Param: "8"},
+				{Name: "members[1].user_id", Reason: "required"},
+			},
+		},
+		{
+			name:            "not a validation error",
+			err:             errors.New("boom"),
+			wantStatus:      http.StatusInternalServerError,
+			wantContentType: ContentTypeJSON,
+			wantCode:        entity.ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/team/add", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			require.NoError(t, WriteValidationError(w, r, tt.err))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+
+			if tt.wantContentType == ContentTypeProblemJSON {
+				var problem entity.ProblemDetails
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.Equal(t, tt.wantCode, problem.Code)
+				assert.Equal(t, tt.wantStatus, problem.Status)
+				assert.Equal(t, "/team/add", problem.Instance)
+				assert.Equal(t, tt.wantParams, problem.InvalidParams)
+				return
+			}
+
+			var resp entity.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error.Code)
+		})
+	}
+}
+
+func TestWantsProblemJSON(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/json", want: false},
+		{accept: "application/problem+json", want: true},
+		{accept: "application/json, application/problem+json", want: true},
+		{accept: "application/json, application/problem+json;q=0.5", want: false},
+		{accept: "application/problem+json;q=0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			assert.Equal(t, tt.want, WantsProblemJSON(r))
+		})
+	}
+}
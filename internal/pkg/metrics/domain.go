@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	domainCollectTimeout = 5 * time.Second
+	// domainCacheTTL bounds how often scrapes hit the database. Several
+	// Prometheus replicas scraping the same instance share one query round.
+	domainCacheTTL = 30 * time.Second
+)
+
+type DomainStatsProvider interface {
+	CountOpenPullRequests(ctx context.Context) (int, error)
+	CountOpenReviewsByTeam(ctx context.Context) (map[string]int, error)
+	CountUnreassignedReviews(ctx context.Context) (int, error)
+}
+
+type domainCollector struct {
+	provider DomainStatsProvider
+
+	openPullRequests    *prometheus.Desc
+	openReviewsByTeam   *prometheus.Desc
+	unreassignedReviews *prometheus.Desc
+
+	mu          sync.Mutex
+	collectedAt time.Time
+	cached      []prometheus.Metric
+}
+
+// NewDomainCollector exposes business gauges. Values are queried from the
+// database at most once per domainCacheTTL; scrapes in between get the cached ones.
+func NewDomainCollector(provider DomainStatsProvider) prometheus.Collector {
+	return &domainCollector{
+		provider: provider,
+		openPullRequests: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_pull_requests"),
+			"Number of pull requests in OPEN status.",
+			nil, nil,
+		),
+		openReviewsByTeam: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "open_reviews"),
+			"Number of review assignments on open pull requests by reviewer team. Reviewers in several teams count in each of them.",
+			[]string{"team"}, nil,
+		),
+		unreassignedReviews: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "unreassigned_reviews"),
+			"Number of review assignments on open pull requests held by inactive reviewers.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *domainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openPullRequests
+	ch <- c.openReviewsByTeam
+	ch <- c.unreassignedReviews
+}
+
+func (c *domainCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached == nil || time.Since(c.collectedAt) >= domainCacheTTL {
+		c.cached = c.collect()
+		c.collectedAt = time.Now()
+	}
+
+	for _, metric := range c.cached {
+		ch <- metric
+	}
+}
+
+func (c *domainCollector) collect() []prometheus.Metric {
+	ctx, cancel := context.WithTimeout(context.Background(), domainCollectTimeout)
+	defer cancel()
+	l := logger.Get()
+
+	out := make([]prometheus.Metric, 0)
+
+	openPRs, err := c.provider.CountOpenPullRequests(ctx)
+	if err != nil {
+		l.Warn("failed to collect open pull requests count", zap.Error(err))
+		out = append(out, prometheus.NewInvalidMetric(c.openPullRequests, err))
+	} else {
+		out = append(out, prometheus.MustNewConstMetric(c.openPullRequests, prometheus.GaugeValue, float64(openPRs)))
+	}
+
+	reviewsByTeam, err := c.provider.CountOpenReviewsByTeam(ctx)
+	if err != nil {
+		l.Warn("failed to collect open reviews by team", zap.Error(err))
+		out = append(out, prometheus.NewInvalidMetric(c.openReviewsByTeam, err))
+	} else {
+		for team, count := range reviewsByTeam {
+			out = append(out, prometheus.MustNewConstMetric(c.openReviewsByTeam, prometheus.GaugeValue, float64(count), team))
+		}
+	}
+
+	unreassigned, err := c.provider.CountUnreassignedReviews(ctx)
+	if err != nil {
+		l.Warn("failed to collect unreassigned reviews count", zap.Error(err))
+		out = append(out, prometheus.NewInvalidMetric(c.unreassignedReviews, err))
+	} else {
+		out = append(out, prometheus.MustNewConstMetric(c.unreassignedReviews, prometheus.GaugeValue, float64(unreassigned)))
+	}
+
+	return out
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "reviewer_service"
+
+type Metrics struct {
+	registry        *prometheus.Registry
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	transactions    *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by route template, method and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route template and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "transactions_total",
+			Help:      "Total number of transactions run through the transaction manager by outcome.",
+		}, []string{"outcome"}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestsTotal,
+		m.requestDuration,
+		m.transactions,
+	)
+
+	return m
+}
+
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+func (m *Metrics) Handler() http.Handler {
+	// ContinueOnError keeps pool and HTTP metrics available when a domain query fails.
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		Registry:      m.registry,
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	m.requestsTotal.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.requestDuration.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+func (m *Metrics) ObserveTransaction(outcome string) {
+	m.transactions.WithLabelValues(outcome).Inc()
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	totalConns      *prometheus.Desc
+	maxConns        *prometheus.Desc
+	acquireCount    *prometheus.Desc
+	emptyAcquire    *prometheus.Desc
+	acquireDuration *prometheus.Desc
+}
+
+// NewPoolCollector exposes pgxpool statistics, read from pool.Stat() on every scrape.
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		pool:            pool,
+		acquiredConns:   desc("acquired_connections", "Number of currently acquired connections."),
+		idleConns:       desc("idle_connections", "Number of currently idle connections."),
+		totalConns:      desc("total_connections", "Total number of connections in the pool."),
+		maxConns:        desc("max_connections", "Maximum size of the pool."),
+		acquireCount:    desc("acquires_total", "Cumulative count of successful acquires from the pool."),
+		emptyAcquire:    desc("empty_acquires_total", "Cumulative count of acquires that had to wait for a connection."),
+		acquireDuration: desc("acquire_wait_seconds_total", "Total time spent waiting for a connection to be acquired."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.emptyAcquire
+	ch <- c.acquireDuration
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+
+	"github.com/avito-tech/go-transaction-manager/trm/v2"
+	trmcontext "github.com/avito-tech/go-transaction-manager/trm/v2/context"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	TransactionCommitted  = "committed"
+	TransactionRolledBack = "rolled_back"
+	TransactionRetryable  = "retryable" // serialization failure or deadlock, safe for the caller to retry
+)
+
+type Transactor struct {
+	next    trm.Manager
+	metrics *Metrics
+}
+
+// NewTransactor wraps trm.Manager and counts top-level transactions by outcome.
+// Nested Do calls join the outer transaction and are not counted twice.
+func NewTransactor(next trm.Manager, m *Metrics) *Transactor {
+	return &Transactor{next: next, metrics: m}
+}
+
+func (t *Transactor) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	nested := trmcontext.DefaultManager.Default(ctx) != nil
+	err := t.next.Do(ctx, fn)
+	if !nested {
+		t.metrics.ObserveTransaction(transactionOutcome(err))
+	}
+	return err
+}
+
+func (t *Transactor) DoWithSettings(ctx context.Context, s trm.Settings, fn func(ctx context.Context) error) error {
+	nested := trmcontext.DefaultManager.Default(ctx) != nil
+	err := t.next.DoWithSettings(ctx, s, fn)
+	if !nested {
+		t.metrics.ObserveTransaction(transactionOutcome(err))
+	}
+	return err
+}
+
+func transactionOutcome(err error) string {
+	if err == nil {
+		return TransactionCommitted
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected {
+			return TransactionRetryable
+		}
+	}
+
+	return TransactionRolledBack
+}
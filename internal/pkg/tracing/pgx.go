@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const dbSystem = "postgresql"
+
+// QueryTracer reports every pgx query and batch as a client span.
+type QueryTracer struct{}
+
+func NewQueryTracer() *QueryTracer {
+	return &QueryTracer{}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !enabled.Load() {
+		return ctx
+	}
+	ctx, _ = Tracer().Start(ctx, "pgx.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dbSystem),
+			attribute.String("db.statement", normalizeSQL(data.SQL)),
+		),
+	)
+	return ctx
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	RecordError(span, data.Err)
+	span.End()
+}
+
+func (t *QueryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	if !enabled.Load() {
+		return ctx
+	}
+	var size int
+	if data.Batch != nil {
+		size = data.Batch.Len()
+	}
+	ctx, _ = Tracer().Start(ctx, "pgx.batch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dbSystem),
+			attribute.Int("db.batch.size", size),
+		),
+	)
+	return ctx
+}
+
+func (t *QueryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.AddEvent("query", trace.WithAttributes(attribute.String("db.statement", normalizeSQL(data.SQL))))
+	RecordError(span, data.Err)
+}
+
+func (t *QueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	RecordError(span, data.Err)
+	span.End()
+}
+
+func normalizeSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
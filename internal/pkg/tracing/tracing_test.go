@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// record installs a provider that keeps finished spans in memory and turns
+// tracing off again once the test is done.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	install(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		enabled.Store(false)
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+	return recorder
+}
+
+func TestStart_Disabled(t *testing.T) {
+	ctx := context.Background()
+
+	gotCtx, span := Start(ctx, "user.GetUser")
+
+	assert.False(t, Enabled())
+	assert.Equal(t, ctx, gotCtx)
+	assert.False(t, span.IsRecording())
+}
+
+func TestStart(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := Start(context.Background(), "user.MassDeactivateUsers", attribute.String("team.name", "backend"))
+	_, child := Start(ctx, "reassignment.Build")
+	RecordError(child, errors.New("boom"))
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "reassignment.Build", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, "user.MassDeactivateUsers", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("team.name", "backend"))
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := record(t)
+	tracer := NewQueryTracer()
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "\n\t\tSELECT id\n\t\tFROM \"user\"\n\t\tWHERE id = $1\n\t\t",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "pgx.query", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.statement", `SELECT id FROM "user" WHERE id = $1`))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64("db.rows_affected", 1))
+}
@@ -29,9 +29,10 @@ const (
 		VALUES ($1, $2)
 		`
 	getPullRequestQuery = `
-		SELECT pr.id, pr.name, pr.author_id, prs.name, pr.merged_at 
+		SELECT pr.id, pr.name, pr.author_id, prs.name, pr.merged_at, COALESCE(q.missing_reviewers, 0)
 		FROM pull_request pr 
         JOIN pull_request_status prs ON pr.status_id = prs.id 
+		LEFT JOIN review_queue q ON q.pull_request_id = pr.id
 		WHERE pr.id = $1
         `
 	getReviewersByPullRequestIDQuery = `
@@ -48,6 +49,10 @@ const (
 		ORDER BY pr.created_at DESC
 		`
 	mergePullRequestByIDQuery = `
+		WITH dequeued AS (
+			DELETE FROM review_queue
+			WHERE pull_request_id = $1
+		)
 		UPDATE pull_request 
 		SET status_id = (SELECT id FROM pull_request_status WHERE name = 'MERGED'), merged_at = NOW() 
 		WHERE id = $1
@@ -80,11 +85,203 @@ const (
 		JOIN pull_request_status s ON s.id = pr.status_id
 		WHERE r.user_id = ANY($1) AND s.name = 'OPEN'
 		`
+	getTeamReviewsByReviewerIDsQuery = `
+		SELECT r.pull_request_id, r.user_id
+		FROM reviewer r
+		JOIN pull_request pr ON pr.id = r.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN team_membership tm ON tm.user_id = pr.author_id AND tm.team_name = $2
+		WHERE r.user_id = ANY($1) AND s.name = 'OPEN'
+		`
+	getTransferableReviewsQuery = `
+		SELECT r.pull_request_id, r.user_id
+		FROM reviewer r
+		JOIN pull_request pr ON pr.id = r.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN team_membership am ON am.user_id = pr.author_id AND am.team_name = $2
+		JOIN team_membership tm ON tm.user_id = r.user_id AND tm.team_name = $2
+		WHERE s.name = 'OPEN' AND pr.author_id <> $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM reviewer own
+				WHERE own.pull_request_id = r.pull_request_id AND own.user_id = $1
+			)
+		ORDER BY pr.created_at DESC, r.pull_request_id, r.user_id
+		`
 	getReviewersByPullRequestIDsQuery = `
 		SELECT pull_request_id, user_id
 		FROM reviewer
 		WHERE pull_request_id = ANY($1)
 		`
+	getAuthorsByPullRequestIDsQuery = `
+		SELECT id, author_id
+		FROM pull_request
+		WHERE id = ANY($1)
+		`
+	getReviewsFingerprintQuery = `
+		SELECT COALESCE(md5(string_agg(r.pull_request_id || E'\t' || r.user_id, E'\n'
+			ORDER BY r.pull_request_id, r.user_id)), '')
+		FROM reviewer r
+		JOIN pull_request pr ON pr.id = r.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		WHERE s.name = 'OPEN'
+			AND r.pull_request_id IN (
+				SELECT pull_request_id
+				FROM reviewer
+				WHERE user_id = ANY($1)
+			)
+		`
+	getOpenPullRequestsByTeamNameQuery = `
+		SELECT pr.id, pr.name, pr.author_id, prs.name, pr.merged_at
+		FROM pull_request pr
+		JOIN pull_request_status prs ON pr.status_id = prs.id
+		JOIN team_membership tm ON tm.user_id = pr.author_id AND tm.team_name = $1
+		WHERE prs.name = 'OPEN'
+		ORDER BY pr.created_at
+		`
+	countOpenPullRequestsQuery = `
+		SELECT COUNT(*)
+		FROM pull_request pr
+		JOIN pull_request_status s ON s.id = pr.status_id
+		WHERE s.name = 'OPEN'
+		`
+	countOpenReviewsByTeamQuery = `
+		SELECT tm.team_name, COUNT(*)
+		FROM reviewer r
+		JOIN pull_request pr ON pr.id = r.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN team_membership tm ON tm.user_id = r.user_id
+		WHERE s.name = 'OPEN'
+		GROUP BY tm.team_name
+		`
+	countUnreassignedReviewsQuery = `
+		SELECT COUNT(*)
+		FROM reviewer r
+		JOIN pull_request pr ON pr.id = r.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN "user" u ON u.id = r.user_id
+		WHERE s.name = 'OPEN' AND u.is_active = false
+		`
+	enqueueAwaitingReviewersQuery = `
+		INSERT INTO review_queue (pull_request_id, missing_reviewers)
+		VALUES ($1, $2)
+		ON CONFLICT (pull_request_id) DO UPDATE
+		SET missing_reviewers = review_queue.missing_reviewers + EXCLUDED.missing_reviewers
+		`
+	addAuditEventQuery = `
+		INSERT INTO review_audit_event (event, team_name, pull_request_id, old_reviewer_id, new_reviewer_id)
+		VALUES ($1, $2, $3, $4, $5)
+		`
+	addQueueReplacementsQuery = `
+		INSERT INTO review_queue_replacement (pull_request_id, reviewer_id)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING
+		`
+	dequeueAwaitingReviewerQuery = `
+		WITH replaced AS (
+			DELETE FROM review_queue_replacement
+			WHERE pull_request_id = $1 AND reviewer_id = (
+				SELECT reviewer_id
+				FROM review_queue_replacement
+				WHERE pull_request_id = $1
+				ORDER BY reviewer_id
+				LIMIT 1
+			)
+			RETURNING reviewer_id
+		), updated AS (
+			UPDATE review_queue
+			SET missing_reviewers = missing_reviewers - 1
+			WHERE pull_request_id = $1 AND missing_reviewers > 1
+			RETURNING pull_request_id
+		), deleted AS (
+			DELETE FROM review_queue
+			WHERE pull_request_id = $1 AND missing_reviewers = 1
+			RETURNING pull_request_id
+		)
+		SELECT EXISTS (SELECT 1 FROM updated) OR EXISTS (SELECT 1 FROM deleted),
+			COALESCE((SELECT reviewer_id FROM replaced), '')
+		`
+	cancelReviewerReplacementsQuery = `
+		WITH cancelled AS (
+			DELETE FROM review_queue_replacement rq
+			WHERE rq.reviewer_id = $1
+				AND EXISTS (
+					SELECT 1
+					FROM reviewer r
+					WHERE r.pull_request_id = rq.pull_request_id AND r.user_id = rq.reviewer_id
+				)
+			RETURNING rq.pull_request_id
+		), updated AS (
+			UPDATE review_queue q
+			SET missing_reviewers = q.missing_reviewers - 1
+			FROM cancelled c
+			WHERE q.pull_request_id = c.pull_request_id AND q.missing_reviewers > 1
+		)
+		DELETE FROM review_queue q
+		USING cancelled c
+		WHERE q.pull_request_id = c.pull_request_id AND q.missing_reviewers = 1
+		`
+	getBacklogQuery = `
+		SELECT q.pull_request_id, pr.name, pr.author_id, COALESCE(NULLIF($1, ''), a.team_name, ''),
+			ARRAY(
+				SELECT r.user_id
+				FROM reviewer r
+				WHERE r.pull_request_id = q.pull_request_id
+				ORDER BY r.user_id
+			),
+			q.missing_reviewers, q.queued_at
+		FROM review_queue q
+		JOIN pull_request pr ON pr.id = q.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN "user" a ON a.id = pr.author_id
+		WHERE s.name = 'OPEN'
+			AND ($1 = '' OR EXISTS (
+				SELECT 1
+				FROM team_membership tm
+				WHERE tm.user_id = pr.author_id AND tm.team_name = $1
+			))
+		ORDER BY q.queued_at, q.pull_request_id
+		`
+	getBacklogCandidatesQuery = `
+		SELECT q.pull_request_id, q.missing_reviewers,
+			ARRAY(
+				SELECT r.user_id
+				FROM reviewer r
+				JOIN "user" ru ON ru.id = r.user_id
+				WHERE r.pull_request_id = q.pull_request_id
+					AND (ru.is_active = false OR EXISTS (
+						SELECT 1
+						FROM user_unavailability rua
+						WHERE rua.user_id = ru.id AND rua.starts_at <= NOW() AND rua.ends_at > NOW()
+					))
+				ORDER BY r.user_id
+			),
+			ARRAY_AGG(u.id ORDER BY u.id)
+		FROM review_queue q
+		JOIN pull_request pr ON pr.id = q.pull_request_id
+		JOIN pull_request_status s ON s.id = pr.status_id
+		JOIN "user" u ON u.id = ANY($1)
+		WHERE s.name = 'OPEN' AND u.id <> pr.author_id
+			AND u.is_active = true AND u.deleted_at IS NULL
+			AND EXISTS (
+				SELECT 1
+				FROM team_membership am
+				JOIN team_membership tm ON tm.team_name = am.team_name
+				WHERE am.user_id = pr.author_id AND tm.user_id = u.id
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM reviewer r
+				WHERE r.pull_request_id = q.pull_request_id AND r.user_id = u.id
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+		GROUP BY q.pull_request_id, q.missing_reviewers, q.queued_at
+		ORDER BY q.queued_at, q.pull_request_id
+		`
 )
 
 type Repo struct {
@@ -153,7 +350,7 @@ func (r *Repo) GetPullRequestByID(ctx context.Context, id string) (*entity.PullR
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
 	pr := &entity.PullRequest{}
-	err := conn.QueryRow(ctx, getPullRequestQuery, id).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.MergedAt)
+	err := conn.QueryRow(ctx, getPullRequestQuery, id).Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.MergedAt, &pr.AwaitingReviewers)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrNotFound
@@ -209,6 +406,33 @@ func (r *Repo) GetPullRequestsByReviewerID(ctx context.Context, userID string) (
 	return pullRequests, nil
 }
 
+func (r *Repo) GetOpenPullRequestsByTeamName(ctx context.Context, teamName string) ([]*entity.PullRequest, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getOpenPullRequestsByTeamNameQuery, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pullRequests := make([]*entity.PullRequest, 0)
+
+	for rows.Next() {
+		pr := &entity.PullRequest{}
+		err = rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.MergedAt)
+		if err != nil {
+			return nil, err
+		}
+		pullRequests = append(pullRequests, pr)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return pullRequests, nil
+}
+
 func (r *Repo) MergePullRequestByID(ctx context.Context, id string) error {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
@@ -336,6 +560,77 @@ func (r *Repo) GetReviewsByReviewerIDs(ctx context.Context, reviewerIDs []string
 	return records, nil
 }
 
+// GetTeamReviewsByReviewerIDs is like GetReviewsByReviewerIDs but only returns
+// reviews on PRs whose authors are primary or secondary members of the team.
+func (r *Repo) GetTeamReviewsByReviewerIDs(ctx context.Context, teamName string, reviewerIDs []string) ([]*entity.ReviewRecord, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getTeamReviewsByReviewerIDsQuery, reviewerIDs, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*entity.ReviewRecord, 0)
+
+	for rows.Next() {
+		record := &entity.ReviewRecord{}
+		if err = rows.Scan(&record.PullRequestID, &record.ReviewerID); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return records, nil
+}
+
+// GetTransferableReviews returns open reviews that userID could take over:
+// reviews held by members of teamName on the team's PRs that userID neither
+// authored nor reviews already. Newest PRs come first, their reviews are the
+// least likely to be in progress.
+func (r *Repo) GetTransferableReviews(ctx context.Context, userID, teamName string) ([]*entity.ReviewRecord, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getTransferableReviewsQuery, userID, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	records := make([]*entity.ReviewRecord, 0)
+
+	for rows.Next() {
+		record := &entity.ReviewRecord{}
+		if err = rows.Scan(&record.PullRequestID, &record.ReviewerID); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return records, nil
+}
+
+// GetReviewsFingerprint returns a hash of all reviewers of the open PRs that
+// reviewerIDs review. It changes whenever a reviewer is added to or removed
+// from such a PR, or the set of these PRs changes.
+func (r *Repo) GetReviewsFingerprint(ctx context.Context, reviewerIDs []string) (string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var fingerprint string
+	if err := conn.QueryRow(ctx, getReviewsFingerprintQuery, reviewerIDs).Scan(&fingerprint); err != nil {
+		return "", err
+	}
+	return fingerprint, nil
+}
+
 func (r *Repo) GetReviewersByPullRequestIDs(ctx context.Context, prIDs []string) (map[string][]string, error) {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
@@ -363,6 +658,34 @@ func (r *Repo) GetReviewersByPullRequestIDs(ctx context.Context, prIDs []string)
 	return result, nil
 }
 
+// GetAuthorsByPullRequestIDs maps each PR ID to the ID of its author.
+func (r *Repo) GetAuthorsByPullRequestIDs(ctx context.Context, prIDs []string) (map[string]string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getAuthorsByPullRequestIDsQuery, prIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]string, len(prIDs))
+
+	for rows.Next() {
+		var prID string
+		var authorID string
+		if err = rows.Scan(&prID, &authorID); err != nil {
+			return nil, err
+		}
+		result[prID] = authorID
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return result, nil
+}
+
 func (r *Repo) RemoveReviewersBatch(ctx context.Context, records []*entity.ReviewRecord) error {
 	if len(records) == 0 {
 		return nil
@@ -428,3 +751,196 @@ func (r *Repo) AddReviewersBatch(ctx context.Context, records []*entity.ReviewRe
 
 	return nil
 }
+
+// AddAuditEvents records reviewer changes made by a bulk operation on the team.
+func (r *Repo) AddAuditEvents(ctx context.Context, event, teamName string, reassignments []*entity.ReviewReassignment) error {
+	if len(reassignments) == 0 {
+		return nil
+	}
+	l := logger.FromCtx(ctx)
+
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	batch := pgx.Batch{}
+	for _, reassignment := range reassignments {
+		batch.Queue(addAuditEventQuery, event, teamName,
+			reassignment.PullRequestID, reassignment.OldReviewerID, reassignment.NewReviewerID)
+	}
+
+	br := conn.SendBatch(ctx, &batch)
+	defer func(br pgx.BatchResults) {
+		err := br.Close()
+		if err != nil {
+			l.Error("error closing batch results: %v\n", zap.Error(err))
+		}
+	}(br)
+
+	for range reassignments {
+		_, err := br.Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Repo) CountOpenPullRequests(ctx context.Context) (int, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var count int
+	if err := conn.QueryRow(ctx, countOpenPullRequestsQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *Repo) CountOpenReviewsByTeam(ctx context.Context) (map[string]int, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, countOpenReviewsByTeamQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]int)
+
+	for rows.Next() {
+		var teamName string
+		var count int
+		if err = rows.Scan(&teamName, &count); err != nil {
+			return nil, err
+		}
+		result[teamName] = count
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return result, nil
+}
+
+func (r *Repo) CountUnreassignedReviews(ctx context.Context) (int, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var count int
+	if err := conn.QueryRow(ctx, countUnreassignedReviewsQuery).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// EnqueueAwaitingReviewers puts the PR into the queue of PRs waiting for
+// missing reviewers, or adds to the number it already waits for.
+func (r *Repo) EnqueueAwaitingReviewers(ctx context.Context, prID string, missing int) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, enqueueAwaitingReviewersQuery, prID, missing)
+	return err
+}
+
+// EnqueueReviewerReplacements queues the PR for one new reviewer per
+// reviewerID, each of them to replace that reviewer.
+func (r *Repo) EnqueueReviewerReplacements(ctx context.Context, prID string, reviewerIDs []string) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, enqueueAwaitingReviewersQuery, prID, len(reviewerIDs)); err != nil {
+		return err
+	}
+	_, err := conn.Exec(ctx, addQueueReplacementsQuery, prID, reviewerIDs)
+	return err
+}
+
+// DequeueAwaitingReviewer takes one missing reviewer off the PR in the queue,
+// dropping the PR once it waits for nobody. Slots queued to replace a reviewer
+// go first, replacedID is that reviewer or empty for a plain missing one. It
+// reports false if the PR no longer waits for anyone, e.g. when a concurrent
+// request filled it first.
+func (r *Repo) DequeueAwaitingReviewer(ctx context.Context, prID string) (replacedID string, dequeued bool, err error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if err = conn.QueryRow(ctx, dequeueAwaitingReviewerQuery, prID).Scan(&dequeued, &replacedID); err != nil {
+		return "", false, err
+	}
+	return replacedID, dequeued, nil
+}
+
+// CancelReviewerReplacements drops the backlog slots queued to replace
+// reviewerID on PRs they still review, since they can take those reviews
+// again, and drops PRs that no longer wait for anyone.
+func (r *Repo) CancelReviewerReplacements(ctx context.Context, reviewerID string) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, cancelReviewerReplacementsQuery, reviewerID)
+	return err
+}
+
+// GetBacklog returns open PRs waiting for reviewers, optionally only those
+// authored by members of teamName.
+func (r *Repo) GetBacklog(ctx context.Context, teamName string) ([]*entity.BacklogEntry, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getBacklogQuery, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*entity.BacklogEntry, 0)
+	for rows.Next() {
+		entry := &entity.BacklogEntry{}
+		if err = rows.Scan(
+			&entry.PullRequestID,
+			&entry.PullRequestName,
+			&entry.AuthorID,
+			&entry.TeamName,
+			&entry.AssignedReviewers,
+			&entry.MissingReviewers,
+			&entry.QueuedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return entries, nil
+}
+
+// GetBacklogCandidates returns backlog PRs that userIDs can review: authored
+// by someone else from their team and not already reviewed by them. Inactive
+// and currently unavailable users are left out.
+func (r *Repo) GetBacklogCandidates(ctx context.Context, userIDs []string) ([]*entity.BacklogCandidates, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getBacklogCandidatesQuery, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*entity.BacklogCandidates, 0)
+	for rows.Next() {
+		candidates := &entity.BacklogCandidates{}
+		if err = rows.Scan(
+			&candidates.PullRequestID,
+			&candidates.MissingReviewers,
+			&candidates.DepartedReviewerIDs,
+			&candidates.CandidateIDs,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, candidates)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return result, nil
+}
@@ -3,6 +3,7 @@ package team
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 
@@ -20,14 +21,82 @@ const (
 		WHERE name = $1
 		`
 	createTeamQuery = `
-		INSERT INTO team (name) 
-		VALUES ($1)
+		WITH created AS (
+			INSERT INTO team (name, parent_name, escalate_to_lead) 
+			VALUES ($1, NULLIF($2, ''), $3)
+			RETURNING name
+		)
+		INSERT INTO team_settings (team_name)
+		SELECT name FROM created
 		`
 	getTeamByNameQuery = `
-		SELECT name 
+		SELECT name, COALESCE(parent_name, ''), escalate_to_lead 
 		FROM team 
 		WHERE name = $1
 		`
+	setLeadEscalationQuery = `
+		UPDATE team
+		SET escalate_to_lead = $2
+		WHERE name = $1
+		`
+	isLeadEscalationEnabledQuery = `
+		SELECT escalate_to_lead
+		FROM team
+		WHERE name = $1
+		`
+	getSettingsQuery = `
+		SELECT team_name, reviewer_count, selection_strategy, review_sla_hours, approval_quorum,
+			author_chooses_reviewers, cross_team_fallback, max_open_reviews, version, updated_at
+		FROM team_settings
+		WHERE team_name = $1
+		`
+	updateSettingsQuery = `
+		UPDATE team_settings
+		SET reviewer_count = $2,
+			selection_strategy = $3,
+			review_sla_hours = $4,
+			approval_quorum = $5,
+			author_chooses_reviewers = $6,
+			cross_team_fallback = $7,
+			max_open_reviews = $8,
+			version = version + 1,
+			updated_at = NOW()
+		WHERE team_name = $1 AND version = $9
+		RETURNING team_name, reviewer_count, selection_strategy, review_sla_hours, approval_quorum,
+			author_chooses_reviewers, cross_team_fallback, max_open_reviews, version, updated_at
+		`
+	setParentQuery = `
+		UPDATE team
+		SET parent_name = NULLIF($2, '')
+		WHERE name = $1
+		`
+	getParentNameQuery = `
+		SELECT COALESCE(parent_name, '')
+		FROM team
+		WHERE name = $1
+		`
+	getChildNamesQuery = `
+		SELECT name
+		FROM team
+		WHERE parent_name = $1
+		ORDER BY name
+		`
+	renameTeamQuery = `
+		UPDATE team
+		SET name = $2
+		WHERE name = $1
+		`
+	archiveTeamQuery = `
+		UPDATE team
+		SET archived_at = COALESCE(archived_at, NOW())
+		WHERE name = $1
+		RETURNING archived_at
+		`
+	isTeamArchivedQuery = `
+		SELECT archived_at IS NOT NULL
+		FROM team
+		WHERE name = $1
+		`
 )
 
 type Repo struct {
@@ -55,7 +124,7 @@ func (r *Repo) CheckTeamNameExists(ctx context.Context, name string) (bool, erro
 func (r *Repo) Create(ctx context.Context, team *entity.Team) error {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
-	_, err := conn.Exec(ctx, createTeamQuery, team.Name)
+	_, err := conn.Exec(ctx, createTeamQuery, team.Name, team.ParentName, team.EscalateToLead)
 	var pgErr *pgconn.PgError
 	if errors.As(err, &pgErr) {
 		if pgErr.Code == pgerrcode.UniqueViolation {
@@ -69,7 +138,7 @@ func (r *Repo) GetByName(ctx context.Context, name string) (*entity.Team, error)
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
 	team := &entity.Team{}
-	err := conn.QueryRow(ctx, getTeamByNameQuery, name).Scan(&team.Name)
+	err := conn.QueryRow(ctx, getTeamByNameQuery, name).Scan(&team.Name, &team.ParentName, &team.EscalateToLead)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrNotFound
@@ -78,3 +147,197 @@ func (r *Repo) GetByName(ctx context.Context, name string) (*entity.Team, error)
 	}
 	return team, nil
 }
+
+// Rename relies on ON UPDATE CASCADE to move members to the new name.
+func (r *Repo) Rename(ctx context.Context, name, newName string) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	result, err := conn.Exec(ctx, renameTeamQuery, name, newName)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.ErrAlreadyExists
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// Archive marks the team as archived and returns when it happened. Archiving
+// an already archived team keeps the original time.
+func (r *Repo) Archive(ctx context.Context, name string) (time.Time, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var archivedAt time.Time
+	err := conn.QueryRow(ctx, archiveTeamQuery, name).Scan(&archivedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, entity.ErrNotFound
+		}
+		return time.Time{}, err
+	}
+	return archivedAt, nil
+}
+
+func (r *Repo) IsArchived(ctx context.Context, name string) (bool, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var archived bool
+	err := conn.QueryRow(ctx, isTeamArchivedQuery, name).Scan(&archived)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return archived, nil
+}
+
+// SetParent attaches the team to parent, or detaches it when parent is empty.
+func (r *Repo) SetParent(ctx context.Context, name, parent string) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	result, err := conn.Exec(ctx, setParentQuery, name, parent)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// GetParentName returns an empty string for top-level and unknown teams.
+func (r *Repo) GetParentName(ctx context.Context, name string) (string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var parent string
+	err := conn.QueryRow(ctx, getParentNameQuery, name).Scan(&parent)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return parent, nil
+}
+
+func (r *Repo) GetChildNames(ctx context.Context, name string) ([]string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getChildNamesQuery, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var child string
+		if err = rows.Scan(&child); err != nil {
+			return nil, err
+		}
+		names = append(names, child)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return names, nil
+}
+
+func (r *Repo) SetLeadEscalation(ctx context.Context, name string, enabled bool) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	result, err := conn.Exec(ctx, setLeadEscalationQuery, name, enabled)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// IsLeadEscalationEnabled returns false for unknown teams.
+func (r *Repo) IsLeadEscalationEnabled(ctx context.Context, name string) (bool, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var enabled bool
+	err := conn.QueryRow(ctx, isLeadEscalationEnabledQuery, name).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return enabled, nil
+}
+
+func (r *Repo) GetSettings(ctx context.Context, name string) (*entity.TeamSettings, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	settings := &entity.TeamSettings{}
+	err := conn.QueryRow(ctx, getSettingsQuery, name).Scan(
+		&settings.TeamName,
+		&settings.ReviewerCount,
+		&settings.SelectionStrategy,
+		&settings.ReviewSLAHours,
+		&settings.ApprovalQuorum,
+		&settings.AuthorChoosesReviewers,
+		&settings.CrossTeamFallback,
+		&settings.MaxOpenReviews,
+		&settings.Version,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings overwrites the settings if they are still at version and
+// returns ErrNotFound otherwise.
+func (r *Repo) UpdateSettings(ctx context.Context, settings *entity.TeamSettings, version int) (*entity.TeamSettings, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	updated := &entity.TeamSettings{}
+	err := conn.QueryRow(ctx, updateSettingsQuery,
+		settings.TeamName,
+		settings.ReviewerCount,
+		settings.SelectionStrategy,
+		settings.ReviewSLAHours,
+		settings.ApprovalQuorum,
+		settings.AuthorChoosesReviewers,
+		settings.CrossTeamFallback,
+		settings.MaxOpenReviews,
+		version,
+	).Scan(
+		&updated.TeamName,
+		&updated.ReviewerCount,
+		&updated.SelectionStrategy,
+		&updated.ReviewSLAHours,
+		&updated.ApprovalQuorum,
+		&updated.AuthorChoosesReviewers,
+		&updated.CrossTeamFallback,
+		&updated.MaxOpenReviews,
+		&updated.Version,
+		&updated.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		return nil, err
+	}
+	return updated, nil
+}
@@ -3,27 +3,80 @@ package user
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
 
+// likeEscaper makes LIKE wildcards in user input match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 const (
 	checkUserExistsQuery = `
 		SELECT 1 FROM "user" WHERE id = $1
 		`
 	createUserQuery = `
-		INSERT INTO "user" (id, username, is_active, team_name) 
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO "user" (id, username, is_active, team_name, email, chat_handle, timezone, seniority,
+			work_hours_start, work_hours_end, max_open_reviews) 
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''),
+			NULLIF($9, '')::time, NULLIF($10, '')::time, $11)
+		`
+	createPrimaryMembershipQuery = `
+		INSERT INTO team_membership (user_id, team_name, is_primary, role)
+		SELECT id, $2, true, COALESCE(NULLIF($3, ''), 'member')
+		FROM "user"
+		WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (user_id, team_name) DO UPDATE SET is_primary = true, role = COALESCE(NULLIF($3, ''), team_membership.role)
+		`
+	dropOtherPrimaryMembershipQuery = `
+		DELETE FROM team_membership
+		WHERE user_id = $1 AND is_primary = true AND team_name <> $2
+		`
+	addSecondaryMembershipQuery = `
+		INSERT INTO team_membership (user_id, team_name, is_primary, role)
+		SELECT id, $2, false, COALESCE(NULLIF($3, ''), 'member')
+		FROM "user"
+		WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (user_id, team_name) DO UPDATE SET role = COALESCE(NULLIF($3, ''), team_membership.role)
+		`
+	getLeadIDsQuery = `
+		SELECT u.id
+		FROM team_membership tm
+		JOIN "user" u ON u.id = tm.user_id
+		WHERE tm.team_name = $1 AND tm.role = 'lead' AND u.id <> ALL($2)
+			AND u.is_active = true AND u.deleted_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_review_load rl
+				WHERE rl.user_id = u.id AND rl.open_reviews >= rl.max_open_reviews
+			)
+		ORDER BY u.id
+		`
+	getTeamMemberIDsQuery = `
+		SELECT user_id
+		FROM team_membership
+		WHERE team_name = $1 AND user_id = ANY($2)
 		`
 	getMembersByTeamNameQuery = `
-		SELECT id, username, is_active 
-		FROM "user" 
-		WHERE team_name = $1
+		SELECT u.id, u.username, u.is_active, tm.role,
+			COALESCE(u.email, ''), COALESCE(u.chat_handle, ''), COALESCE(u.timezone, ''), COALESCE(u.seniority, ''),
+			COALESCE(to_char(u.work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(u.work_hours_end, 'HH24:MI'), '')
+		FROM team_membership tm
+		JOIN "user" u ON u.id = tm.user_id 
+		WHERE tm.team_name = $1
 		`
 	findExistingByIDsQuery = `
 		SELECT id 
@@ -32,50 +85,270 @@ const (
 		`
 	updateUsersQuery = `
 		UPDATE "user" 
-		SET username = $2, is_active = $3, team_name = $4 
+		SET username = $2, is_active = $3, team_name = $4,
+			email = COALESCE(NULLIF($5, ''), email),
+			chat_handle = COALESCE(NULLIF($6, ''), chat_handle),
+			timezone = COALESCE(NULLIF($7, ''), timezone),
+			seniority = COALESCE(NULLIF($8, ''), seniority),
+			work_hours_start = COALESCE(NULLIF($9, '')::time, work_hours_start),
+			work_hours_end = COALESCE(NULLIF($10, '')::time, work_hours_end)
+		WHERE id = $1 AND deleted_at IS NULL
+		`
+	updateProfileQuery = `
+		UPDATE "user" 
+		SET username = $2,
+			email = NULLIF($3, ''),
+			chat_handle = NULLIF($4, ''),
+			timezone = NULLIF($5, ''),
+			seniority = NULLIF($6, ''),
+			work_hours_start = NULLIF($7, '')::time,
+			work_hours_end = NULLIF($8, '')::time,
+			max_open_reviews = $9
 		WHERE id = $1
 		`
+	searchUsersQuery = `
+		SELECT id, username, is_active, COALESCE(team_name, ''),
+			COALESCE(email, ''), COALESCE(chat_handle, ''), COALESCE(timezone, ''), COALESCE(seniority, ''),
+			COALESCE(to_char(work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(work_hours_end, 'HH24:MI'), ''),
+			max_open_reviews, deleted_at
+		FROM "user"
+		WHERE (id LIKE $1 || '%' OR username ILIKE '%' || $1 || '%') AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT $2 OFFSET $3
+		`
 	setIsActiveQuery = `
 		UPDATE "user" 
 		SET is_active = $2 
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 		`
 	getUserByIDQuery = `
-		SELECT id, username, is_active, team_name 
+		SELECT id, username, is_active, COALESCE(team_name, ''),
+			COALESCE(email, ''), COALESCE(chat_handle, ''), COALESCE(timezone, ''), COALESCE(seniority, ''),
+			COALESCE(to_char(work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(work_hours_end, 'HH24:MI'), ''),
+			max_open_reviews, deleted_at
 		FROM "user" 
 		WHERE id = $1
 		`
+	// Timezones are validated by Go, whose zone database may differ from the
+	// one of Postgres; unknown zones fall back to UTC instead of failing.
 	getReviewersForPRQuery = `
-		SELECT id, username, is_active, team_name 
-		FROM "user" 
-		WHERE team_name = $1 AND id <> $2 AND is_active = true 
-		ORDER BY RANDOM() LIMIT 2
+		WITH known_tz AS MATERIALIZED (
+			SELECT name FROM pg_timezone_names WHERE $4 = 'working_hours'
+		)
+		SELECT u.id, u.username, u.is_active, COALESCE(u.team_name, ''),
+			COALESCE(u.email, ''), COALESCE(u.chat_handle, ''), COALESCE(u.timezone, ''), COALESCE(u.seniority, ''),
+			COALESCE(to_char(u.work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(u.work_hours_end, 'HH24:MI'), ''),
+			u.max_open_reviews, u.deleted_at
+		FROM team_membership tm
+		JOIN "user" u ON u.id = tm.user_id 
+		WHERE tm.team_name = $1 AND u.id <> ALL ($2) AND u.is_active = true
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_review_load rl
+				WHERE rl.user_id = u.id AND rl.open_reviews >= rl.max_open_reviews
+			)
+		ORDER BY CASE WHEN $4 = 'least_loaded' THEN (
+			SELECT COUNT(*)
+			FROM reviewer r
+			JOIN pull_request pr ON pr.id = r.pull_request_id
+			JOIN pull_request_status s ON s.id = pr.status_id
+			WHERE r.user_id = u.id AND s.name = 'OPEN'
+		) ELSE 0 END,
+		CASE WHEN $4 = 'working_hours' THEN (
+			SELECT CASE
+				WHEN h.ws = h.we THEN 0
+				WHEN h.ws < h.we AND h.lt >= h.ws AND h.lt < h.we THEN 0
+				WHEN h.ws > h.we AND (h.lt >= h.ws OR h.lt < h.we) THEN 0
+				ELSE MOD(EXTRACT(EPOCH FROM h.ws - h.lt)::numeric + 86400, 86400)
+			END
+			FROM (
+				SELECT COALESCE(u.work_hours_start, TIME '09:00') AS ws,
+					COALESCE(u.work_hours_end, TIME '18:00') AS we,
+					(NOW() AT TIME ZONE COALESCE(
+						(SELECT tz.name FROM known_tz tz WHERE tz.name = u.timezone), 'UTC'
+					))::time AS lt
+			) h
+		) ELSE 0 END, RANDOM()
+		LIMIT $3
 		`
-	getReviewerForPRQuery = `
-		SELECT id, username, is_active, team_name 
-		FROM "user"
-		WHERE team_name = $1 AND id <> ALL ($2) AND is_active = true 
-		ORDER BY RANDOM() LIMIT 1
+	getActiveUsersFromTeamsQuery = `
+		SELECT u.id, u.username, u.is_active, COALESCE(u.team_name, ''),
+			COALESCE(u.email, ''), COALESCE(u.chat_handle, ''), COALESCE(u.timezone, ''), COALESCE(u.seniority, ''),
+			COALESCE(to_char(u.work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(u.work_hours_end, 'HH24:MI'), ''),
+			u.max_open_reviews, u.deleted_at
+		FROM "user" u
+		WHERE EXISTS (
+			SELECT 1
+			FROM team_membership tm
+			WHERE tm.user_id = u.id AND tm.team_name = ANY($1)
+		) AND u.id <> ALL($2) AND u.is_active = true
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_review_load rl
+				WHERE rl.user_id = u.id AND rl.open_reviews >= rl.max_open_reviews
+			)
+		ORDER BY RANDOM() LIMIT $3
 		`
 	getAllUsersIDsQuery = `
 		SELECT id
 		FROM "user"
 		`
 	getUsersByIDsQuery = `
-		SELECT id, username, is_active, team_name
+		SELECT id, username, is_active, COALESCE(team_name, ''),
+			COALESCE(email, ''), COALESCE(chat_handle, ''), COALESCE(timezone, ''), COALESCE(seniority, ''),
+			COALESCE(to_char(work_hours_start, 'HH24:MI'), ''), COALESCE(to_char(work_hours_end, 'HH24:MI'), ''),
+			max_open_reviews, deleted_at
 		FROM "user"
 		WHERE id = ANY($1)
 		`
 	getActiveUsersIDsByTeamNameQuery = `
-		SELECT id
-		FROM "user"
-		WHERE team_name = $1 AND is_active = true AND id <> ALL($2)
+		SELECT u.id
+		FROM team_membership tm
+		JOIN "user" u ON u.id = tm.user_id
+		WHERE tm.team_name = $1 AND u.is_active = true AND u.id <> ALL($2)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_review_load rl
+				WHERE rl.user_id = u.id AND rl.open_reviews >= rl.max_open_reviews
+			)
+		`
+	getAvailableMemberIDsQuery = `
+		SELECT u.id
+		FROM team_membership tm
+		JOIN "user" u ON u.id = tm.user_id
+		WHERE tm.team_name = $1 AND u.is_active = true
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_unavailability ua
+				WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+			)
+		ORDER BY u.id
 		`
 	deactivateUsersQuery = `
 		UPDATE "user"
 		SET is_active = false
 		WHERE id = ANY($1)
 		`
+	removeMembershipsQuery = `
+		DELETE FROM team_membership
+		WHERE team_name = $1 AND user_id = ANY($2)
+		`
+	removeAllMembershipsQuery = `
+		DELETE FROM team_membership
+		WHERE user_id = $1
+		`
+	deleteUserQuery = `
+		UPDATE "user"
+		SET id = 'deleted-' || substr(md5(random()::text || id), 1, 16),
+			original_id = CASE WHEN $2 THEN NULL ELSE id END,
+			is_active = false,
+			team_name = NULL,
+			deleted_at = NOW(),
+			username = CASE WHEN $2 THEN 'deleted user' ELSE username END,
+			email = CASE WHEN $2 THEN NULL ELSE email END,
+			chat_handle = CASE WHEN $2 THEN NULL ELSE chat_handle END,
+			timezone = CASE WHEN $2 THEN NULL ELSE timezone END,
+			seniority = CASE WHEN $2 THEN NULL ELSE seniority END,
+			work_hours_start = CASE WHEN $2 THEN NULL ELSE work_hours_start END,
+			work_hours_end = CASE WHEN $2 THEN NULL ELSE work_hours_end END
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING id, deleted_at
+		`
+	isUnavailableQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_unavailability
+			WHERE user_id = $1 AND starts_at <= NOW() AND ends_at > NOW()
+		)
+		`
+	hasCappedCandidatesQuery = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM team_membership tm
+			JOIN "user" u ON u.id = tm.user_id
+			JOIN user_review_load rl ON rl.user_id = u.id
+			WHERE tm.team_name = $1 AND u.id <> ALL($2) AND u.is_active = true
+				AND rl.open_reviews >= rl.max_open_reviews
+				AND NOT EXISTS (
+					SELECT 1
+					FROM user_unavailability ua
+					WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+				)
+		)
+		`
+	getReviewCapacitiesQuery = `
+		SELECT user_id, GREATEST(max_open_reviews - open_reviews, 0)
+		FROM user_review_load
+		WHERE user_id = ANY($1) AND max_open_reviews IS NOT NULL
+		`
+	removeExpiredDeactivationPlansQuery = `
+		DELETE FROM deactivation_plan
+		WHERE expires_at <= NOW()
+		`
+	saveDeactivationPlanQuery = `
+		INSERT INTO deactivation_plan (token, team_name, user_ids, fingerprint, reassignments, unreassigned, teams, expires_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8)
+		`
+	takeDeactivationPlanQuery = `
+		DELETE FROM deactivation_plan
+		WHERE token = $1 AND expires_at > NOW()
+		RETURNING token, COALESCE(team_name, ''), user_ids, fingerprint, reassignments, unreassigned, teams, expires_at
+		`
+	removeUserUnavailabilityQuery = `
+		DELETE FROM user_unavailability
+		WHERE user_id = $1
+		`
+	addUnavailabilityQuery = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+		`
+	getUnavailabilityByUserIDQuery = `
+		SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at, id
+		`
+	removeUnavailabilityQuery = `
+		DELETE FROM user_unavailability
+		WHERE id = $1 AND user_id = $2
+		`
+	claimStartedAbsenceQuery = `
+		SELECT id, user_id, starts_at, ends_at, reason, reviews_reassigned_at
+		FROM user_unavailability
+		WHERE reviews_reassigned_at IS NULL
+			AND starts_at <= NOW() AND ends_at > NOW()
+			AND ends_at - starts_at >= make_interval(days => $1)
+			AND id <> ALL($2)
+		ORDER BY starts_at, id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+		`
+	markAbsencesHandledQuery = `
+		UPDATE user_unavailability
+		SET reviews_reassigned_at = NOW()
+		WHERE id = ANY($1)
+		`
+	removeFromTeamQuery = `
+		UPDATE "user"
+		SET team_name = NULL
+		WHERE team_name = $1 AND id = ANY($2)
+		`
 )
 
 type Repo struct {
@@ -83,6 +356,12 @@ type Repo struct {
 	getter *trmpgx.CtxGetter
 }
 
+func scanUser(row pgx.Row, user *entity.User) error {
+	return row.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName,
+		&user.Email, &user.ChatHandle, &user.Timezone, &user.Seniority,
+		&user.WorkHoursStart, &user.WorkHoursEnd, &user.MaxOpenReviews, &user.DeletedAt)
+}
+
 func NewUserRepo(db *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repo {
 	return &Repo{db: db, getter: getter}
 }
@@ -106,7 +385,10 @@ func (r *Repo) CreateBatch(ctx context.Context, members []*entity.Member, teamNa
 
 	batch := pgx.Batch{}
 	for _, member := range members {
-		batch.Queue(createUserQuery, member.ID, member.Username, member.IsActive, teamName)
+		batch.Queue(createUserQuery, member.ID, member.Username, member.IsActive, teamName,
+			member.Email, member.ChatHandle, member.Timezone, member.Seniority,
+			member.WorkHoursStart, member.WorkHoursEnd, nil)
+		batch.Queue(createPrimaryMembershipQuery, member.ID, teamName, member.Role)
 	}
 
 	br := conn.SendBatch(ctx, &batch)
@@ -117,7 +399,7 @@ func (r *Repo) CreateBatch(ctx context.Context, members []*entity.Member, teamNa
 		}
 	}(br)
 
-	for range members {
+	for range batch.Len() {
 		_, err := br.Exec()
 		if err != nil {
 			return err
@@ -140,7 +422,9 @@ func (r *Repo) GetByTeamName(ctx context.Context, teamName string) ([]*entity.Me
 
 	for rows.Next() {
 		member := &entity.Member{}
-		err = rows.Scan(&member.ID, &member.Username, &member.IsActive)
+		err = rows.Scan(&member.ID, &member.Username, &member.IsActive, &member.Role,
+			&member.Email, &member.ChatHandle, &member.Timezone, &member.Seniority,
+			&member.WorkHoursStart, &member.WorkHoursEnd)
 		if err != nil {
 			return nil, err
 		}
@@ -185,7 +469,11 @@ func (r *Repo) UpdateMembers(ctx context.Context, members []*entity.Member, team
 
 	batch := pgx.Batch{}
 	for _, member := range members {
-		batch.Queue(updateUsersQuery, member.ID, member.Username, member.IsActive, teamName)
+		batch.Queue(updateUsersQuery, member.ID, member.Username, member.IsActive, teamName,
+			member.Email, member.ChatHandle, member.Timezone, member.Seniority,
+			member.WorkHoursStart, member.WorkHoursEnd)
+		batch.Queue(dropOtherPrimaryMembershipQuery, member.ID, teamName)
+		batch.Queue(createPrimaryMembershipQuery, member.ID, teamName, member.Role)
 	}
 
 	br := conn.SendBatch(ctx, &batch)
@@ -205,11 +493,98 @@ func (r *Repo) UpdateMembers(ctx context.Context, members []*entity.Member, team
 		if result.RowsAffected() == 0 {
 			return entity.ErrNotFound
 		}
+
+		for range 2 {
+			if _, err = br.Exec(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Create inserts a single user and, if the user has a team, makes it their
+// primary membership.
+func (r *Repo) Create(ctx context.Context, user *entity.User) error {
+	l := logger.FromCtx(ctx)
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	batch := pgx.Batch{}
+	batch.Queue(createUserQuery, user.ID, user.Username, user.IsActive, user.TeamName,
+		user.Email, user.ChatHandle, user.Timezone, user.Seniority,
+		user.WorkHoursStart, user.WorkHoursEnd, user.MaxOpenReviews)
+	if user.TeamName != "" {
+		batch.Queue(createPrimaryMembershipQuery, user.ID, user.TeamName, entity.RoleMember)
+	}
+
+	br := conn.SendBatch(ctx, &batch)
+	defer func(br pgx.BatchResults) {
+		err := br.Close()
+		if err != nil {
+			l.Error("error closing batch results: %v\n", zap.Error(err))
+		}
+	}(br)
+
+	for range batch.Len() {
+		_, err := br.Exec()
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return entity.ErrAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// UpdateProfile overwrites the editable profile fields of the user.
+func (r *Repo) UpdateProfile(ctx context.Context, user *entity.User) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	result, err := conn.Exec(ctx, updateProfileQuery, user.ID, user.Username, user.Email, user.ChatHandle, user.Timezone, user.Seniority,
+		user.WorkHoursStart, user.WorkHoursEnd, user.MaxOpenReviews)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// Search returns users whose ID starts with query or whose username contains
+// it, case-insensitively, ordered by ID.
+func (r *Repo) Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, searchUsersQuery, likeEscaper.Replace(query), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*entity.User, 0)
+
+	for rows.Next() {
+		user := &entity.User{}
+		err = scanUser(rows, user)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return users, nil
+}
+
 func (r *Repo) SetIsActive(ctx context.Context, id string, isActive bool) error {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
@@ -227,7 +602,7 @@ func (r *Repo) SetIsActive(ctx context.Context, id string, isActive bool) error
 func (r *Repo) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 	user := &entity.User{}
-	err := conn.QueryRow(ctx, getUserByIDQuery, id).Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
+	err := scanUser(conn.QueryRow(ctx, getUserByIDQuery, id), user)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrNotFound
@@ -237,10 +612,12 @@ func (r *Repo) GetUserByID(ctx context.Context, id string) (*entity.User, error)
 	return user, nil
 }
 
-func (r *Repo) GetReviewersForPullRequest(ctx context.Context, teamName, excludeUserID string) ([]*entity.User, error) {
+// GetReviewersForPullRequest picks up to limit active members of teamName
+// ordered by strategy: at random, or least open reviews first.
+func (r *Repo) GetReviewersForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, limit int, strategy string) ([]*entity.User, error) {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
-	rows, err := conn.Query(ctx, getReviewersForPRQuery, teamName, excludeUserID)
+	rows, err := conn.Query(ctx, getReviewersForPRQuery, teamName, excludeUserIDs, limit, strategy)
 	if err != nil {
 		return nil, err
 	}
@@ -250,7 +627,7 @@ func (r *Repo) GetReviewersForPullRequest(ctx context.Context, teamName, exclude
 
 	for rows.Next() {
 		user := &entity.User{}
-		err = rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
+		err = scanUser(rows, user)
 		if err != nil {
 			return nil, err
 		}
@@ -264,19 +641,15 @@ func (r *Repo) GetReviewersForPullRequest(ctx context.Context, teamName, exclude
 	return users, nil
 }
 
-func (r *Repo) GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string) (*entity.User, error) {
-	conn := r.getter.DefaultTrOrDB(ctx, r.db)
-
-	row := conn.QueryRow(ctx, getReviewerForPRQuery, teamName, excludeUserIDs)
-	user := &entity.User{}
-	err := row.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
+func (r *Repo) GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, strategy string) (*entity.User, error) {
+	users, err := r.GetReviewersForPullRequest(ctx, teamName, excludeUserIDs, 1, strategy)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, entity.ErrNotFound
-		}
 		return nil, err
 	}
-	return user, nil
+	if len(users) == 0 {
+		return nil, entity.ErrNotFound
+	}
+	return users[0], nil
 }
 
 func (r *Repo) GetAllUsersIDs(ctx context.Context) ([]string, error) {
@@ -319,7 +692,7 @@ func (r *Repo) GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User,
 
 	for rows.Next() {
 		user := &entity.User{}
-		if err = rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName); err != nil {
+		if err = scanUser(rows, user); err != nil {
 			return nil, err
 		}
 		users = append(users, user)
@@ -358,6 +731,34 @@ func (r *Repo) GetActiveUsersIDsByTeamName(ctx context.Context, teamName string,
 	return ids, nil
 }
 
+// GetAvailableMemberIDs returns active members of the team that are not on
+// leave, including those at their cap of open reviews.
+func (r *Repo) GetAvailableMemberIDs(ctx context.Context, teamName string) ([]string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getAvailableMemberIDsQuery, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return ids, nil
+}
+
 func (r *Repo) DeactivateUsers(ctx context.Context, ids []string) error {
 	conn := r.getter.DefaultTrOrDB(ctx, r.db)
 
@@ -368,3 +769,329 @@ func (r *Repo) DeactivateUsers(ctx context.Context, ids []string) error {
 
 	return nil
 }
+
+// RemoveFromTeam drops the users' membership in the team. Users whose primary
+// team it was are left without one.
+func (r *Repo) RemoveFromTeam(ctx context.Context, teamName string, ids []string) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, removeMembershipsQuery, teamName, ids); err != nil {
+		return err
+	}
+
+	if _, err := conn.Exec(ctx, removeFromTeamQuery, teamName, ids); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetActiveUsersFromTeams picks up to limit random active users from any of
+// teamNames. It is used for cross-team reviewer fallback.
+func (r *Repo) GetActiveUsersFromTeams(ctx context.Context, teamNames, excludeIDs []string, limit int) ([]*entity.User, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getActiveUsersFromTeamsQuery, teamNames, excludeIDs, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*entity.User, 0, limit)
+	for rows.Next() {
+		user := &entity.User{}
+		if err = scanUser(rows, user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return users, nil
+}
+
+// AddSecondaryMemberships adds the members to the team without changing their
+// primary team. Roles of existing memberships are updated.
+func (r *Repo) AddSecondaryMemberships(ctx context.Context, teamName string, members []*entity.Member) error {
+	l := logger.FromCtx(ctx)
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	batch := pgx.Batch{}
+	for _, member := range members {
+		batch.Queue(addSecondaryMembershipQuery, member.ID, teamName, member.Role)
+	}
+
+	br := conn.SendBatch(ctx, &batch)
+	defer func(br pgx.BatchResults) {
+		err := br.Close()
+		if err != nil {
+			l.Error("error closing batch results: %v\n", zap.Error(err))
+		}
+	}(br)
+
+	for range members {
+		if _, err := br.Exec(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTeamMemberIDs returns those of ids that are primary or secondary members
+// of the team.
+func (r *Repo) GetTeamMemberIDs(ctx context.Context, teamName string, ids []string) ([]string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getTeamMemberIDsQuery, teamName, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	memberIDs := make([]string, 0, len(ids))
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		memberIDs = append(memberIDs, id)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return memberIDs, nil
+}
+
+// GetLeadIDs returns leads of the team who could be picked as reviewers
+// right now: active, available and below their review cap.
+func (r *Repo) GetLeadIDs(ctx context.Context, teamName string, excludeIDs []string) ([]string, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getLeadIDsQuery, teamName, excludeIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leadIDs := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		leadIDs = append(leadIDs, id)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return leadIDs, nil
+}
+
+// Delete soft-deletes the user under a new pseudonymous ID, which replaces the
+// old one on their PRs and reviews. With erase the username and profile are
+// scrubbed and the original ID is forgotten.
+func (r *Repo) Delete(ctx context.Context, id string, erase bool) (string, time.Time, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, removeAllMembershipsQuery, id); err != nil {
+		return "", time.Time{}, err
+	}
+	if _, err := conn.Exec(ctx, removeUserUnavailabilityQuery, id); err != nil {
+		return "", time.Time{}, err
+	}
+
+	var pseudonym string
+	var deletedAt time.Time
+	err := conn.QueryRow(ctx, deleteUserQuery, id, erase).Scan(&pseudonym, &deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", time.Time{}, entity.ErrNotFound
+		}
+		return "", time.Time{}, err
+	}
+	return pseudonym, deletedAt, nil
+}
+
+func (r *Repo) AddUnavailability(ctx context.Context, unavailability *entity.Unavailability) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	return conn.QueryRow(ctx, addUnavailabilityQuery,
+		unavailability.UserID,
+		unavailability.StartsAt,
+		unavailability.EndsAt,
+		unavailability.Reason,
+	).Scan(&unavailability.ID)
+}
+
+func (r *Repo) GetUnavailabilityByUserID(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getUnavailabilityByUserIDQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanUnavailability(rows)
+}
+
+func (r *Repo) RemoveUnavailability(ctx context.Context, userID string, id int64) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	tag, err := conn.Exec(ctx, removeUnavailabilityQuery, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ErrNotFound
+	}
+	return nil
+}
+
+// ClaimStartedAbsence locks the earliest absence of at least minDays that is
+// in progress, whose reviews were not handed over yet and that is not in
+// skipIDs. Rows locked by a concurrent transaction are skipped, so several
+// instances can run the handover.
+func (r *Repo) ClaimStartedAbsence(ctx context.Context, minDays int, skipIDs []int64) (*entity.Unavailability, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var u entity.Unavailability
+	err := conn.QueryRow(ctx, claimStartedAbsenceQuery, minDays, skipIDs).
+		Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.ReviewsReassignedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *Repo) MarkAbsencesHandled(ctx context.Context, ids []int64) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	_, err := conn.Exec(ctx, markAbsencesHandledQuery, ids)
+	return err
+}
+
+func scanUnavailability(rows pgx.Rows) ([]*entity.Unavailability, error) {
+	result := make([]*entity.Unavailability, 0)
+	for rows.Next() {
+		var u entity.Unavailability
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.ReviewsReassignedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, &u)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return result, nil
+}
+
+// IsUnavailable tells whether the user is away right now.
+func (r *Repo) IsUnavailable(ctx context.Context, userID string) (bool, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var unavailable bool
+	if err := conn.QueryRow(ctx, isUnavailableQuery, userID).Scan(&unavailable); err != nil {
+		return false, err
+	}
+	return unavailable, nil
+}
+
+// HasCappedCandidates tells whether the team has active, available members
+// outside excludeIDs that are skipped only because they reached their cap.
+func (r *Repo) HasCappedCandidates(ctx context.Context, teamName string, excludeIDs []string) (bool, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	var exists bool
+	if err := conn.QueryRow(ctx, hasCappedCandidatesQuery, teamName, excludeIDs).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+// GetReviewCapacities returns how many more open reviews each of ids can take.
+// Users without a cap are left out of the map.
+func (r *Repo) GetReviewCapacities(ctx context.Context, ids []string) (map[string]int, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	rows, err := conn.Query(ctx, getReviewCapacitiesQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	capacities := make(map[string]int)
+	for rows.Next() {
+		var id string
+		var capacity int
+		if err = rows.Scan(&id, &capacity); err != nil {
+			return nil, err
+		}
+		capacities[id] = capacity
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return capacities, nil
+}
+
+// SaveDeactivationPlan stores a previewed plan and drops expired ones.
+func (r *Repo) SaveDeactivationPlan(ctx context.Context, plan *entity.DeactivationPlan) error {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	if _, err := conn.Exec(ctx, removeExpiredDeactivationPlansQuery); err != nil {
+		return err
+	}
+
+	_, err := conn.Exec(ctx, saveDeactivationPlanQuery,
+		plan.Token,
+		plan.TeamName,
+		plan.UserIDs,
+		plan.Fingerprint,
+		plan.Reassignments,
+		plan.Unreassigned,
+		plan.Teams,
+		plan.ExpiresAt,
+	)
+	return err
+}
+
+// TakeDeactivationPlan removes the plan with the token and returns it, so a
+// plan is applied at most once. Expired plans are not returned.
+func (r *Repo) TakeDeactivationPlan(ctx context.Context, token string) (*entity.DeactivationPlan, error) {
+	conn := r.getter.DefaultTrOrDB(ctx, r.db)
+
+	plan := &entity.DeactivationPlan{}
+	err := conn.QueryRow(ctx, takeDeactivationPlanQuery, token).Scan(
+		&plan.Token,
+		&plan.TeamName,
+		&plan.UserIDs,
+		&plan.Fingerprint,
+		&plan.Reassignments,
+		&plan.Unreassigned,
+		&plan.Teams,
+		&plan.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entity.ErrNotFound
+		}
+		return nil, err
+	}
+	return plan, nil
+}
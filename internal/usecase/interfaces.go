@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 )
@@ -10,22 +11,52 @@ type TeamRepository interface {
 	CheckTeamNameExists(ctx context.Context, name string) (bool, error)
 	Create(ctx context.Context, team *entity.Team) error
 	GetByName(ctx context.Context, name string) (*entity.Team, error)
+	Rename(ctx context.Context, name, newName string) error
+	Archive(ctx context.Context, name string) (time.Time, error)
+	IsArchived(ctx context.Context, name string) (bool, error)
+	SetParent(ctx context.Context, name, parent string) error
+	GetParentName(ctx context.Context, name string) (string, error)
+	GetChildNames(ctx context.Context, name string) ([]string, error)
+	SetLeadEscalation(ctx context.Context, name string, enabled bool) error
+	IsLeadEscalationEnabled(ctx context.Context, name string) (bool, error)
+	GetSettings(ctx context.Context, name string) (*entity.TeamSettings, error)
+	UpdateSettings(ctx context.Context, settings *entity.TeamSettings, version int) (*entity.TeamSettings, error)
 }
 
 type UserRepository interface {
 	CheckUserExists(ctx context.Context, id string) (bool, error)
 	CreateBatch(ctx context.Context, members []*entity.Member, teamName string) error
+	Create(ctx context.Context, user *entity.User) error
+	UpdateProfile(ctx context.Context, user *entity.User) error
+	Search(ctx context.Context, query string, limit, offset int) ([]*entity.User, error)
+	Delete(ctx context.Context, id string, erase bool) (string, time.Time, error)
 	GetByTeamName(ctx context.Context, teamName string) ([]*entity.Member, error)
 	FindExistingByIDs(ctx context.Context, ids []string) (map[string]struct{}, error)
 	UpdateMembers(ctx context.Context, members []*entity.Member, teamName string) error
 	SetIsActive(ctx context.Context, id string, isActive bool) error
 	GetUserByID(ctx context.Context, id string) (*entity.User, error)
-	GetReviewersForPullRequest(ctx context.Context, teamName, excludeUserID string) ([]*entity.User, error)
-	GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string) (*entity.User, error)
+	GetReviewersForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, limit int, strategy string) ([]*entity.User, error)
+	GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, strategy string) (*entity.User, error)
+	GetActiveUsersFromTeams(ctx context.Context, teamNames, excludeIDs []string, limit int) ([]*entity.User, error)
 	GetAllUsersIDs(ctx context.Context) ([]string, error)
 	GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User, error)
 	GetActiveUsersIDsByTeamName(ctx context.Context, teamName string, excludeIDs []string) ([]string, error)
+	GetAvailableMemberIDs(ctx context.Context, teamName string) ([]string, error)
 	DeactivateUsers(ctx context.Context, ids []string) error
+	RemoveFromTeam(ctx context.Context, teamName string, ids []string) error
+	AddSecondaryMemberships(ctx context.Context, teamName string, members []*entity.Member) error
+	GetLeadIDs(ctx context.Context, teamName string, excludeIDs []string) ([]string, error)
+	GetTeamMemberIDs(ctx context.Context, teamName string, ids []string) ([]string, error)
+	AddUnavailability(ctx context.Context, unavailability *entity.Unavailability) error
+	GetUnavailabilityByUserID(ctx context.Context, userID string) ([]*entity.Unavailability, error)
+	RemoveUnavailability(ctx context.Context, userID string, id int64) error
+	ClaimStartedAbsence(ctx context.Context, minDays int, skipIDs []int64) (*entity.Unavailability, error)
+	MarkAbsencesHandled(ctx context.Context, ids []int64) error
+	IsUnavailable(ctx context.Context, userID string) (bool, error)
+	HasCappedCandidates(ctx context.Context, teamName string, excludeIDs []string) (bool, error)
+	GetReviewCapacities(ctx context.Context, ids []string) (map[string]int, error)
+	SaveDeactivationPlan(ctx context.Context, plan *entity.DeactivationPlan) error
+	TakeDeactivationPlan(ctx context.Context, token string) (*entity.DeactivationPlan, error)
 }
 
 type PullRequestRepository interface {
@@ -35,13 +66,25 @@ type PullRequestRepository interface {
 	GetPullRequestByID(ctx context.Context, id string) (*entity.PullRequest, error)
 	GetReviewersByPullRequestID(ctx context.Context, id string) ([]string, error)
 	GetPullRequestsByReviewerID(ctx context.Context, userID string) ([]*entity.PullRequest, error)
+	GetOpenPullRequestsByTeamName(ctx context.Context, teamName string) ([]*entity.PullRequest, error)
 	MergePullRequestByID(ctx context.Context, id string) error
 	RemoveReviewer(ctx context.Context, prID, userID string) error
 	AddNewReviewer(ctx context.Context, prID, userID string) error
 	GetOpenAndMergedReviewStatisticsForUsers(ctx context.Context) (map[string]int, map[string]int, error) // first map is open PRs, second is merged PRs
 	GetAuthorStatisticsForUsers(ctx context.Context) (map[string]int, error)
 	GetReviewsByReviewerIDs(ctx context.Context, reviewerIDs []string) ([]*entity.ReviewRecord, error)
+	GetTeamReviewsByReviewerIDs(ctx context.Context, teamName string, reviewerIDs []string) ([]*entity.ReviewRecord, error)
 	GetReviewersByPullRequestIDs(ctx context.Context, prIDs []string) (map[string][]string, error)
+	GetAuthorsByPullRequestIDs(ctx context.Context, prIDs []string) (map[string]string, error)
+	GetReviewsFingerprint(ctx context.Context, reviewerIDs []string) (string, error)
+	GetTransferableReviews(ctx context.Context, userID, teamName string) ([]*entity.ReviewRecord, error)
 	RemoveReviewersBatch(ctx context.Context, records []*entity.ReviewRecord) error
 	AddReviewersBatch(ctx context.Context, records []*entity.ReviewRecord) error
+	AddAuditEvents(ctx context.Context, event, teamName string, reassignments []*entity.ReviewReassignment) error
+	EnqueueAwaitingReviewers(ctx context.Context, prID string, missing int) error
+	EnqueueReviewerReplacements(ctx context.Context, prID string, reviewerIDs []string) error
+	DequeueAwaitingReviewer(ctx context.Context, prID string) (replacedID string, dequeued bool, err error)
+	CancelReviewerReplacements(ctx context.Context, reviewerID string) error
+	GetBacklog(ctx context.Context, teamName string) ([]*entity.BacklogEntry, error)
+	GetBacklogCandidates(ctx context.Context, userIDs []string) ([]*entity.BacklogCandidates, error)
 }
@@ -38,6 +38,75 @@ func (_m *MockPullRequestRepository) EXPECT() *MockPullRequestRepository_Expecte
 	return &MockPullRequestRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddAuditEvents provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) AddAuditEvents(ctx context.Context, event string, teamName string, reassignments []*entity.ReviewReassignment) error {
+	ret := _mock.Called(ctx, event, teamName, reassignments)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuditEvents")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []*entity.ReviewReassignment) error); ok {
+		r0 = returnFunc(ctx, event, teamName, reassignments)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPullRequestRepository_AddAuditEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditEvents'
+type MockPullRequestRepository_AddAuditEvents_Call struct {
+	*mock.Call
+}
+
+// AddAuditEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event string
+//   - teamName string
+//   - reassignments []*entity.ReviewReassignment
+func (_e *MockPullRequestRepository_Expecter) AddAuditEvents(ctx interface{}, event interface{}, teamName interface{}, reassignments interface{}) *MockPullRequestRepository_AddAuditEvents_Call {
+	return &MockPullRequestRepository_AddAuditEvents_Call{Call: _e.mock.On("AddAuditEvents", ctx, event, teamName, reassignments)}
+}
+
+func (_c *MockPullRequestRepository_AddAuditEvents_Call) Run(run func(ctx context.Context, event string, teamName string, reassignments []*entity.ReviewReassignment)) *MockPullRequestRepository_AddAuditEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []*entity.ReviewReassignment
+		if args[3] != nil {
+			arg3 = args[3].([]*entity.ReviewReassignment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_AddAuditEvents_Call) Return(err error) *MockPullRequestRepository_AddAuditEvents_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_AddAuditEvents_Call) RunAndReturn(run func(ctx context.Context, event string, teamName string, reassignments []*entity.ReviewReassignment) error) *MockPullRequestRepository_AddAuditEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddNewReviewer provides a mock function for the type MockPullRequestRepository
 func (_mock *MockPullRequestRepository) AddNewReviewer(ctx context.Context, prID string, userID string) error {
 	ret := _mock.Called(ctx, prID, userID)
@@ -221,6 +290,63 @@ func (_c *MockPullRequestRepository_AssignReviewers_Call) RunAndReturn(run func(
 	return _c
 }
 
+// CancelReviewerReplacements provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) CancelReviewerReplacements(ctx context.Context, reviewerID string) error {
+	ret := _mock.Called(ctx, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReviewerReplacements")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, reviewerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPullRequestRepository_CancelReviewerReplacements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelReviewerReplacements'
+type MockPullRequestRepository_CancelReviewerReplacements_Call struct {
+	*mock.Call
+}
+
+// CancelReviewerReplacements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviewerID string
+func (_e *MockPullRequestRepository_Expecter) CancelReviewerReplacements(ctx interface{}, reviewerID interface{}) *MockPullRequestRepository_CancelReviewerReplacements_Call {
+	return &MockPullRequestRepository_CancelReviewerReplacements_Call{Call: _e.mock.On("CancelReviewerReplacements", ctx, reviewerID)}
+}
+
+func (_c *MockPullRequestRepository_CancelReviewerReplacements_Call) Run(run func(ctx context.Context, reviewerID string)) *MockPullRequestRepository_CancelReviewerReplacements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_CancelReviewerReplacements_Call) Return(err error) *MockPullRequestRepository_CancelReviewerReplacements_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_CancelReviewerReplacements_Call) RunAndReturn(run func(ctx context.Context, reviewerID string) error) *MockPullRequestRepository_CancelReviewerReplacements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckPullRequestIDExists provides a mock function for the type MockPullRequestRepository
 func (_mock *MockPullRequestRepository) CheckPullRequestIDExists(ctx context.Context, id string) (bool, error) {
 	ret := _mock.Called(ctx, id)
@@ -301,30 +427,426 @@ func (_mock *MockPullRequestRepository) Create(ctx context.Context, pr *entity.P
 	} else {
 		r0 = ret.Error(0)
 	}
-	return r0
+	return r0
+}
+
+// MockPullRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPullRequestRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr *entity.PullRequest
+func (_e *MockPullRequestRepository_Expecter) Create(ctx interface{}, pr interface{}) *MockPullRequestRepository_Create_Call {
+	return &MockPullRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, pr)}
+}
+
+func (_c *MockPullRequestRepository_Create_Call) Run(run func(ctx context.Context, pr *entity.PullRequest)) *MockPullRequestRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.PullRequest
+		if args[1] != nil {
+			arg1 = args[1].(*entity.PullRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_Create_Call) Return(err error) *MockPullRequestRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_Create_Call) RunAndReturn(run func(ctx context.Context, pr *entity.PullRequest) error) *MockPullRequestRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DequeueAwaitingReviewer provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) DequeueAwaitingReviewer(ctx context.Context, prID string) (string, bool, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DequeueAwaitingReviewer")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, bool, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, prID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockPullRequestRepository_DequeueAwaitingReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DequeueAwaitingReviewer'
+type MockPullRequestRepository_DequeueAwaitingReviewer_Call struct {
+	*mock.Call
+}
+
+// DequeueAwaitingReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockPullRequestRepository_Expecter) DequeueAwaitingReviewer(ctx interface{}, prID interface{}) *MockPullRequestRepository_DequeueAwaitingReviewer_Call {
+	return &MockPullRequestRepository_DequeueAwaitingReviewer_Call{Call: _e.mock.On("DequeueAwaitingReviewer", ctx, prID)}
+}
+
+func (_c *MockPullRequestRepository_DequeueAwaitingReviewer_Call) Run(run func(ctx context.Context, prID string)) *MockPullRequestRepository_DequeueAwaitingReviewer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_DequeueAwaitingReviewer_Call) Return(s string, b bool, err error) *MockPullRequestRepository_DequeueAwaitingReviewer_Call {
+	_c.Call.Return(s, b, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_DequeueAwaitingReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string) (string, bool, error)) *MockPullRequestRepository_DequeueAwaitingReviewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueAwaitingReviewers provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) EnqueueAwaitingReviewers(ctx context.Context, prID string, missing int) error {
+	ret := _mock.Called(ctx, prID, missing)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueAwaitingReviewers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, prID, missing)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPullRequestRepository_EnqueueAwaitingReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueAwaitingReviewers'
+type MockPullRequestRepository_EnqueueAwaitingReviewers_Call struct {
+	*mock.Call
+}
+
+// EnqueueAwaitingReviewers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - missing int
+func (_e *MockPullRequestRepository_Expecter) EnqueueAwaitingReviewers(ctx interface{}, prID interface{}, missing interface{}) *MockPullRequestRepository_EnqueueAwaitingReviewers_Call {
+	return &MockPullRequestRepository_EnqueueAwaitingReviewers_Call{Call: _e.mock.On("EnqueueAwaitingReviewers", ctx, prID, missing)}
+}
+
+func (_c *MockPullRequestRepository_EnqueueAwaitingReviewers_Call) Run(run func(ctx context.Context, prID string, missing int)) *MockPullRequestRepository_EnqueueAwaitingReviewers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_EnqueueAwaitingReviewers_Call) Return(err error) *MockPullRequestRepository_EnqueueAwaitingReviewers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_EnqueueAwaitingReviewers_Call) RunAndReturn(run func(ctx context.Context, prID string, missing int) error) *MockPullRequestRepository_EnqueueAwaitingReviewers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueReviewerReplacements provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) EnqueueReviewerReplacements(ctx context.Context, prID string, reviewerIDs []string) error {
+	ret := _mock.Called(ctx, prID, reviewerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueReviewerReplacements")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, prID, reviewerIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPullRequestRepository_EnqueueReviewerReplacements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueReviewerReplacements'
+type MockPullRequestRepository_EnqueueReviewerReplacements_Call struct {
+	*mock.Call
+}
+
+// EnqueueReviewerReplacements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - reviewerIDs []string
+func (_e *MockPullRequestRepository_Expecter) EnqueueReviewerReplacements(ctx interface{}, prID interface{}, reviewerIDs interface{}) *MockPullRequestRepository_EnqueueReviewerReplacements_Call {
+	return &MockPullRequestRepository_EnqueueReviewerReplacements_Call{Call: _e.mock.On("EnqueueReviewerReplacements", ctx, prID, reviewerIDs)}
+}
+
+func (_c *MockPullRequestRepository_EnqueueReviewerReplacements_Call) Run(run func(ctx context.Context, prID string, reviewerIDs []string)) *MockPullRequestRepository_EnqueueReviewerReplacements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_EnqueueReviewerReplacements_Call) Return(err error) *MockPullRequestRepository_EnqueueReviewerReplacements_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_EnqueueReviewerReplacements_Call) RunAndReturn(run func(ctx context.Context, prID string, reviewerIDs []string) error) *MockPullRequestRepository_EnqueueReviewerReplacements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorStatisticsForUsers provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetAuthorStatisticsForUsers(ctx context.Context) (map[string]int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorStatisticsForUsers")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetAuthorStatisticsForUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorStatisticsForUsers'
+type MockPullRequestRepository_GetAuthorStatisticsForUsers_Call struct {
+	*mock.Call
+}
+
+// GetAuthorStatisticsForUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPullRequestRepository_Expecter) GetAuthorStatisticsForUsers(ctx interface{}) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+	return &MockPullRequestRepository_GetAuthorStatisticsForUsers_Call{Call: _e.mock.On("GetAuthorStatisticsForUsers", ctx)}
+}
+
+func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) Run(run func(ctx context.Context)) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) Return(stringToInt map[string]int, err error) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+	_c.Call.Return(stringToInt, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) RunAndReturn(run func(ctx context.Context) (map[string]int, error)) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAuthorsByPullRequestIDs provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetAuthorsByPullRequestIDs(ctx context.Context, prIDs []string) (map[string]string, error) {
+	ret := _mock.Called(ctx, prIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorsByPullRequestIDs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return returnFunc(ctx, prIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = returnFunc(ctx, prIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, prIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorsByPullRequestIDs'
+type MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call struct {
+	*mock.Call
+}
+
+// GetAuthorsByPullRequestIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prIDs []string
+func (_e *MockPullRequestRepository_Expecter) GetAuthorsByPullRequestIDs(ctx interface{}, prIDs interface{}) *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call {
+	return &MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call{Call: _e.mock.On("GetAuthorsByPullRequestIDs", ctx, prIDs)}
+}
+
+func (_c *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call) Run(run func(ctx context.Context, prIDs []string)) *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call) Return(stringToString map[string]string, err error) *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call {
+	_c.Call.Return(stringToString, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call) RunAndReturn(run func(ctx context.Context, prIDs []string) (map[string]string, error)) *MockPullRequestRepository_GetAuthorsByPullRequestIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBacklog provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetBacklog(ctx context.Context, teamName string) ([]*entity.BacklogEntry, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklog")
+	}
+
+	var r0 []*entity.BacklogEntry
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.BacklogEntry, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.BacklogEntry); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BacklogEntry)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockPullRequestRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockPullRequestRepository_Create_Call struct {
+// MockPullRequestRepository_GetBacklog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklog'
+type MockPullRequestRepository_GetBacklog_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// GetBacklog is a helper method to define mock.On call
 //   - ctx context.Context
-//   - pr *entity.PullRequest
-func (_e *MockPullRequestRepository_Expecter) Create(ctx interface{}, pr interface{}) *MockPullRequestRepository_Create_Call {
-	return &MockPullRequestRepository_Create_Call{Call: _e.mock.On("Create", ctx, pr)}
+//   - teamName string
+func (_e *MockPullRequestRepository_Expecter) GetBacklog(ctx interface{}, teamName interface{}) *MockPullRequestRepository_GetBacklog_Call {
+	return &MockPullRequestRepository_GetBacklog_Call{Call: _e.mock.On("GetBacklog", ctx, teamName)}
 }
 
-func (_c *MockPullRequestRepository_Create_Call) Run(run func(ctx context.Context, pr *entity.PullRequest)) *MockPullRequestRepository_Create_Call {
+func (_c *MockPullRequestRepository_GetBacklog_Call) Run(run func(ctx context.Context, teamName string)) *MockPullRequestRepository_GetBacklog_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *entity.PullRequest
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*entity.PullRequest)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -334,74 +856,80 @@ func (_c *MockPullRequestRepository_Create_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockPullRequestRepository_Create_Call) Return(err error) *MockPullRequestRepository_Create_Call {
-	_c.Call.Return(err)
+func (_c *MockPullRequestRepository_GetBacklog_Call) Return(backlogEntrys []*entity.BacklogEntry, err error) *MockPullRequestRepository_GetBacklog_Call {
+	_c.Call.Return(backlogEntrys, err)
 	return _c
 }
 
-func (_c *MockPullRequestRepository_Create_Call) RunAndReturn(run func(ctx context.Context, pr *entity.PullRequest) error) *MockPullRequestRepository_Create_Call {
+func (_c *MockPullRequestRepository_GetBacklog_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]*entity.BacklogEntry, error)) *MockPullRequestRepository_GetBacklog_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAuthorStatisticsForUsers provides a mock function for the type MockPullRequestRepository
-func (_mock *MockPullRequestRepository) GetAuthorStatisticsForUsers(ctx context.Context) (map[string]int, error) {
-	ret := _mock.Called(ctx)
+// GetBacklogCandidates provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetBacklogCandidates(ctx context.Context, userIDs []string) ([]*entity.BacklogCandidates, error) {
+	ret := _mock.Called(ctx, userIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAuthorStatisticsForUsers")
+		panic("no return value specified for GetBacklogCandidates")
 	}
 
-	var r0 map[string]int
+	var r0 []*entity.BacklogCandidates
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]int, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entity.BacklogCandidates, error)); ok {
+		return returnFunc(ctx, userIDs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]int); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entity.BacklogCandidates); ok {
+		r0 = returnFunc(ctx, userIDs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]int)
+			r0 = ret.Get(0).([]*entity.BacklogCandidates)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPullRequestRepository_GetAuthorStatisticsForUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorStatisticsForUsers'
-type MockPullRequestRepository_GetAuthorStatisticsForUsers_Call struct {
+// MockPullRequestRepository_GetBacklogCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklogCandidates'
+type MockPullRequestRepository_GetBacklogCandidates_Call struct {
 	*mock.Call
 }
 
-// GetAuthorStatisticsForUsers is a helper method to define mock.On call
+// GetBacklogCandidates is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockPullRequestRepository_Expecter) GetAuthorStatisticsForUsers(ctx interface{}) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
-	return &MockPullRequestRepository_GetAuthorStatisticsForUsers_Call{Call: _e.mock.On("GetAuthorStatisticsForUsers", ctx)}
+//   - userIDs []string
+func (_e *MockPullRequestRepository_Expecter) GetBacklogCandidates(ctx interface{}, userIDs interface{}) *MockPullRequestRepository_GetBacklogCandidates_Call {
+	return &MockPullRequestRepository_GetBacklogCandidates_Call{Call: _e.mock.On("GetBacklogCandidates", ctx, userIDs)}
 }
 
-func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) Run(run func(ctx context.Context)) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+func (_c *MockPullRequestRepository_GetBacklogCandidates_Call) Run(run func(ctx context.Context, userIDs []string)) *MockPullRequestRepository_GetBacklogCandidates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) Return(stringToInt map[string]int, err error) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
-	_c.Call.Return(stringToInt, err)
+func (_c *MockPullRequestRepository_GetBacklogCandidates_Call) Return(backlogCandidatess []*entity.BacklogCandidates, err error) *MockPullRequestRepository_GetBacklogCandidates_Call {
+	_c.Call.Return(backlogCandidatess, err)
 	return _c
 }
 
-func (_c *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call) RunAndReturn(run func(ctx context.Context) (map[string]int, error)) *MockPullRequestRepository_GetAuthorStatisticsForUsers_Call {
+func (_c *MockPullRequestRepository_GetBacklogCandidates_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) ([]*entity.BacklogCandidates, error)) *MockPullRequestRepository_GetBacklogCandidates_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -476,6 +1004,74 @@ func (_c *MockPullRequestRepository_GetOpenAndMergedReviewStatisticsForUsers_Cal
 	return _c
 }
 
+// GetOpenPullRequestsByTeamName provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetOpenPullRequestsByTeamName(ctx context.Context, teamName string) ([]*entity.PullRequest, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenPullRequestsByTeamName")
+	}
+
+	var r0 []*entity.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.PullRequest, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.PullRequest); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenPullRequestsByTeamName'
+type MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call struct {
+	*mock.Call
+}
+
+// GetOpenPullRequestsByTeamName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockPullRequestRepository_Expecter) GetOpenPullRequestsByTeamName(ctx interface{}, teamName interface{}) *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call {
+	return &MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call{Call: _e.mock.On("GetOpenPullRequestsByTeamName", ctx, teamName)}
+}
+
+func (_c *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call) Return(pullRequests []*entity.PullRequest, err error) *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call {
+	_c.Call.Return(pullRequests, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]*entity.PullRequest, error)) *MockPullRequestRepository_GetOpenPullRequestsByTeamName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPullRequestByID provides a mock function for the type MockPullRequestRepository
 func (_mock *MockPullRequestRepository) GetPullRequestByID(ctx context.Context, id string) (*entity.PullRequest, error) {
 	ret := _mock.Called(ctx, id)
@@ -816,6 +1412,220 @@ func (_c *MockPullRequestRepository_GetReviewsByReviewerIDs_Call) RunAndReturn(r
 	return _c
 }
 
+// GetReviewsFingerprint provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetReviewsFingerprint(ctx context.Context, reviewerIDs []string) (string, error) {
+	ret := _mock.Called(ctx, reviewerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewsFingerprint")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (string, error)); ok {
+		return returnFunc(ctx, reviewerIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) string); ok {
+		r0 = returnFunc(ctx, reviewerIDs)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, reviewerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetReviewsFingerprint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewsFingerprint'
+type MockPullRequestRepository_GetReviewsFingerprint_Call struct {
+	*mock.Call
+}
+
+// GetReviewsFingerprint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviewerIDs []string
+func (_e *MockPullRequestRepository_Expecter) GetReviewsFingerprint(ctx interface{}, reviewerIDs interface{}) *MockPullRequestRepository_GetReviewsFingerprint_Call {
+	return &MockPullRequestRepository_GetReviewsFingerprint_Call{Call: _e.mock.On("GetReviewsFingerprint", ctx, reviewerIDs)}
+}
+
+func (_c *MockPullRequestRepository_GetReviewsFingerprint_Call) Run(run func(ctx context.Context, reviewerIDs []string)) *MockPullRequestRepository_GetReviewsFingerprint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetReviewsFingerprint_Call) Return(s string, err error) *MockPullRequestRepository_GetReviewsFingerprint_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetReviewsFingerprint_Call) RunAndReturn(run func(ctx context.Context, reviewerIDs []string) (string, error)) *MockPullRequestRepository_GetReviewsFingerprint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamReviewsByReviewerIDs provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetTeamReviewsByReviewerIDs(ctx context.Context, teamName string, reviewerIDs []string) ([]*entity.ReviewRecord, error) {
+	ret := _mock.Called(ctx, teamName, reviewerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamReviewsByReviewerIDs")
+	}
+
+	var r0 []*entity.ReviewRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]*entity.ReviewRecord, error)); ok {
+		return returnFunc(ctx, teamName, reviewerIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []*entity.ReviewRecord); ok {
+		r0 = returnFunc(ctx, teamName, reviewerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ReviewRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, reviewerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamReviewsByReviewerIDs'
+type MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call struct {
+	*mock.Call
+}
+
+// GetTeamReviewsByReviewerIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - reviewerIDs []string
+func (_e *MockPullRequestRepository_Expecter) GetTeamReviewsByReviewerIDs(ctx interface{}, teamName interface{}, reviewerIDs interface{}) *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call {
+	return &MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call{Call: _e.mock.On("GetTeamReviewsByReviewerIDs", ctx, teamName, reviewerIDs)}
+}
+
+func (_c *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call) Run(run func(ctx context.Context, teamName string, reviewerIDs []string)) *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call) Return(reviewRecords []*entity.ReviewRecord, err error) *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call {
+	_c.Call.Return(reviewRecords, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call) RunAndReturn(run func(ctx context.Context, teamName string, reviewerIDs []string) ([]*entity.ReviewRecord, error)) *MockPullRequestRepository_GetTeamReviewsByReviewerIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferableReviews provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) GetTransferableReviews(ctx context.Context, userID string, teamName string) ([]*entity.ReviewRecord, error) {
+	ret := _mock.Called(ctx, userID, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferableReviews")
+	}
+
+	var r0 []*entity.ReviewRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*entity.ReviewRecord, error)); ok {
+		return returnFunc(ctx, userID, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*entity.ReviewRecord); ok {
+		r0 = returnFunc(ctx, userID, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.ReviewRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_GetTransferableReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferableReviews'
+type MockPullRequestRepository_GetTransferableReviews_Call struct {
+	*mock.Call
+}
+
+// GetTransferableReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - teamName string
+func (_e *MockPullRequestRepository_Expecter) GetTransferableReviews(ctx interface{}, userID interface{}, teamName interface{}) *MockPullRequestRepository_GetTransferableReviews_Call {
+	return &MockPullRequestRepository_GetTransferableReviews_Call{Call: _e.mock.On("GetTransferableReviews", ctx, userID, teamName)}
+}
+
+func (_c *MockPullRequestRepository_GetTransferableReviews_Call) Run(run func(ctx context.Context, userID string, teamName string)) *MockPullRequestRepository_GetTransferableReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetTransferableReviews_Call) Return(reviewRecords []*entity.ReviewRecord, err error) *MockPullRequestRepository_GetTransferableReviews_Call {
+	_c.Call.Return(reviewRecords, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_GetTransferableReviews_Call) RunAndReturn(run func(ctx context.Context, userID string, teamName string) ([]*entity.ReviewRecord, error)) *MockPullRequestRepository_GetTransferableReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MergePullRequestByID provides a mock function for the type MockPullRequestRepository
 func (_mock *MockPullRequestRepository) MergePullRequestByID(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
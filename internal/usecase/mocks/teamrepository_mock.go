@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,72 @@ func (_m *MockTeamRepository) EXPECT() *MockTeamRepository_Expecter {
 	return &MockTeamRepository_Expecter{mock: &_m.Mock}
 }
 
+// Archive provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) Archive(ctx context.Context, name string) (time.Time, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type MockTeamRepository_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) Archive(ctx interface{}, name interface{}) *MockTeamRepository_Archive_Call {
+	return &MockTeamRepository_Archive_Call{Call: _e.mock.On("Archive", ctx, name)}
+}
+
+func (_c *MockTeamRepository_Archive_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_Archive_Call) Return(time1 time.Time, err error) *MockTeamRepository_Archive_Call {
+	_c.Call.Return(time1, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_Archive_Call) RunAndReturn(run func(ctx context.Context, name string) (time.Time, error)) *MockTeamRepository_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckTeamNameExists provides a mock function for the type MockTeamRepository
 func (_mock *MockTeamRepository) CheckTeamNameExists(ctx context.Context, name string) (bool, error) {
 	ret := _mock.Called(ctx, name)
@@ -228,3 +295,600 @@ func (_c *MockTeamRepository_GetByName_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetChildNames provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) GetChildNames(ctx context.Context, name string) ([]string, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChildNames")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_GetChildNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChildNames'
+type MockTeamRepository_GetChildNames_Call struct {
+	*mock.Call
+}
+
+// GetChildNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) GetChildNames(ctx interface{}, name interface{}) *MockTeamRepository_GetChildNames_Call {
+	return &MockTeamRepository_GetChildNames_Call{Call: _e.mock.On("GetChildNames", ctx, name)}
+}
+
+func (_c *MockTeamRepository_GetChildNames_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_GetChildNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_GetChildNames_Call) Return(strings []string, err error) *MockTeamRepository_GetChildNames_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_GetChildNames_Call) RunAndReturn(run func(ctx context.Context, name string) ([]string, error)) *MockTeamRepository_GetChildNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParentName provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) GetParentName(ctx context.Context, name string) (string, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParentName")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_GetParentName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParentName'
+type MockTeamRepository_GetParentName_Call struct {
+	*mock.Call
+}
+
+// GetParentName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) GetParentName(ctx interface{}, name interface{}) *MockTeamRepository_GetParentName_Call {
+	return &MockTeamRepository_GetParentName_Call{Call: _e.mock.On("GetParentName", ctx, name)}
+}
+
+func (_c *MockTeamRepository_GetParentName_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_GetParentName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_GetParentName_Call) Return(s string, err error) *MockTeamRepository_GetParentName_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_GetParentName_Call) RunAndReturn(run func(ctx context.Context, name string) (string, error)) *MockTeamRepository_GetParentName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSettings provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) GetSettings(ctx context.Context, name string) (*entity.TeamSettings, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSettings")
+	}
+
+	var r0 *entity.TeamSettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.TeamSettings, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.TeamSettings); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TeamSettings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_GetSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettings'
+type MockTeamRepository_GetSettings_Call struct {
+	*mock.Call
+}
+
+// GetSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) GetSettings(ctx interface{}, name interface{}) *MockTeamRepository_GetSettings_Call {
+	return &MockTeamRepository_GetSettings_Call{Call: _e.mock.On("GetSettings", ctx, name)}
+}
+
+func (_c *MockTeamRepository_GetSettings_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_GetSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_GetSettings_Call) Return(teamSettings *entity.TeamSettings, err error) *MockTeamRepository_GetSettings_Call {
+	_c.Call.Return(teamSettings, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_GetSettings_Call) RunAndReturn(run func(ctx context.Context, name string) (*entity.TeamSettings, error)) *MockTeamRepository_GetSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsArchived provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) IsArchived(ctx context.Context, name string) (bool, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsArchived")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_IsArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsArchived'
+type MockTeamRepository_IsArchived_Call struct {
+	*mock.Call
+}
+
+// IsArchived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) IsArchived(ctx interface{}, name interface{}) *MockTeamRepository_IsArchived_Call {
+	return &MockTeamRepository_IsArchived_Call{Call: _e.mock.On("IsArchived", ctx, name)}
+}
+
+func (_c *MockTeamRepository_IsArchived_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_IsArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_IsArchived_Call) Return(b bool, err error) *MockTeamRepository_IsArchived_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_IsArchived_Call) RunAndReturn(run func(ctx context.Context, name string) (bool, error)) *MockTeamRepository_IsArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsLeadEscalationEnabled provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) IsLeadEscalationEnabled(ctx context.Context, name string) (bool, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsLeadEscalationEnabled")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_IsLeadEscalationEnabled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsLeadEscalationEnabled'
+type MockTeamRepository_IsLeadEscalationEnabled_Call struct {
+	*mock.Call
+}
+
+// IsLeadEscalationEnabled is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockTeamRepository_Expecter) IsLeadEscalationEnabled(ctx interface{}, name interface{}) *MockTeamRepository_IsLeadEscalationEnabled_Call {
+	return &MockTeamRepository_IsLeadEscalationEnabled_Call{Call: _e.mock.On("IsLeadEscalationEnabled", ctx, name)}
+}
+
+func (_c *MockTeamRepository_IsLeadEscalationEnabled_Call) Run(run func(ctx context.Context, name string)) *MockTeamRepository_IsLeadEscalationEnabled_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_IsLeadEscalationEnabled_Call) Return(b bool, err error) *MockTeamRepository_IsLeadEscalationEnabled_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_IsLeadEscalationEnabled_Call) RunAndReturn(run func(ctx context.Context, name string) (bool, error)) *MockTeamRepository_IsLeadEscalationEnabled_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) Rename(ctx context.Context, name string, newName string) error {
+	ret := _mock.Called(ctx, name, newName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, name, newName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamRepository_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockTeamRepository_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - newName string
+func (_e *MockTeamRepository_Expecter) Rename(ctx interface{}, name interface{}, newName interface{}) *MockTeamRepository_Rename_Call {
+	return &MockTeamRepository_Rename_Call{Call: _e.mock.On("Rename", ctx, name, newName)}
+}
+
+func (_c *MockTeamRepository_Rename_Call) Run(run func(ctx context.Context, name string, newName string)) *MockTeamRepository_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_Rename_Call) Return(err error) *MockTeamRepository_Rename_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamRepository_Rename_Call) RunAndReturn(run func(ctx context.Context, name string, newName string) error) *MockTeamRepository_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLeadEscalation provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) SetLeadEscalation(ctx context.Context, name string, enabled bool) error {
+	ret := _mock.Called(ctx, name, enabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLeadEscalation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, name, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamRepository_SetLeadEscalation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLeadEscalation'
+type MockTeamRepository_SetLeadEscalation_Call struct {
+	*mock.Call
+}
+
+// SetLeadEscalation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - enabled bool
+func (_e *MockTeamRepository_Expecter) SetLeadEscalation(ctx interface{}, name interface{}, enabled interface{}) *MockTeamRepository_SetLeadEscalation_Call {
+	return &MockTeamRepository_SetLeadEscalation_Call{Call: _e.mock.On("SetLeadEscalation", ctx, name, enabled)}
+}
+
+func (_c *MockTeamRepository_SetLeadEscalation_Call) Run(run func(ctx context.Context, name string, enabled bool)) *MockTeamRepository_SetLeadEscalation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_SetLeadEscalation_Call) Return(err error) *MockTeamRepository_SetLeadEscalation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamRepository_SetLeadEscalation_Call) RunAndReturn(run func(ctx context.Context, name string, enabled bool) error) *MockTeamRepository_SetLeadEscalation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetParent provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) SetParent(ctx context.Context, name string, parent string) error {
+	ret := _mock.Called(ctx, name, parent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, name, parent)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamRepository_SetParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParent'
+type MockTeamRepository_SetParent_Call struct {
+	*mock.Call
+}
+
+// SetParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - parent string
+func (_e *MockTeamRepository_Expecter) SetParent(ctx interface{}, name interface{}, parent interface{}) *MockTeamRepository_SetParent_Call {
+	return &MockTeamRepository_SetParent_Call{Call: _e.mock.On("SetParent", ctx, name, parent)}
+}
+
+func (_c *MockTeamRepository_SetParent_Call) Run(run func(ctx context.Context, name string, parent string)) *MockTeamRepository_SetParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_SetParent_Call) Return(err error) *MockTeamRepository_SetParent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamRepository_SetParent_Call) RunAndReturn(run func(ctx context.Context, name string, parent string) error) *MockTeamRepository_SetParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSettings provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) UpdateSettings(ctx context.Context, settings *entity.TeamSettings, version int) (*entity.TeamSettings, error) {
+	ret := _mock.Called(ctx, settings, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSettings")
+	}
+
+	var r0 *entity.TeamSettings
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.TeamSettings, int) (*entity.TeamSettings, error)); ok {
+		return returnFunc(ctx, settings, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.TeamSettings, int) *entity.TeamSettings); ok {
+		r0 = returnFunc(ctx, settings, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TeamSettings)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.TeamSettings, int) error); ok {
+		r1 = returnFunc(ctx, settings, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_UpdateSettings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSettings'
+type MockTeamRepository_UpdateSettings_Call struct {
+	*mock.Call
+}
+
+// UpdateSettings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - settings *entity.TeamSettings
+//   - version int
+func (_e *MockTeamRepository_Expecter) UpdateSettings(ctx interface{}, settings interface{}, version interface{}) *MockTeamRepository_UpdateSettings_Call {
+	return &MockTeamRepository_UpdateSettings_Call{Call: _e.mock.On("UpdateSettings", ctx, settings, version)}
+}
+
+func (_c *MockTeamRepository_UpdateSettings_Call) Run(run func(ctx context.Context, settings *entity.TeamSettings, version int)) *MockTeamRepository_UpdateSettings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.TeamSettings
+		if args[1] != nil {
+			arg1 = args[1].(*entity.TeamSettings)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_UpdateSettings_Call) Return(teamSettings *entity.TeamSettings, err error) *MockTeamRepository_UpdateSettings_Call {
+	_c.Call.Return(teamSettings, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_UpdateSettings_Call) RunAndReturn(run func(ctx context.Context, settings *entity.TeamSettings, version int) (*entity.TeamSettings, error)) *MockTeamRepository_UpdateSettings_Call {
+	_c.Call.Return(run)
+	return _c
+}
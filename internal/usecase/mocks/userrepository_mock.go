@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,126 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddSecondaryMemberships provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddSecondaryMemberships(ctx context.Context, teamName string, members []*entity.Member) error {
+	ret := _mock.Called(ctx, teamName, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSecondaryMemberships")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*entity.Member) error); ok {
+		r0 = returnFunc(ctx, teamName, members)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddSecondaryMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddSecondaryMemberships'
+type MockUserRepository_AddSecondaryMemberships_Call struct {
+	*mock.Call
+}
+
+// AddSecondaryMemberships is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - members []*entity.Member
+func (_e *MockUserRepository_Expecter) AddSecondaryMemberships(ctx interface{}, teamName interface{}, members interface{}) *MockUserRepository_AddSecondaryMemberships_Call {
+	return &MockUserRepository_AddSecondaryMemberships_Call{Call: _e.mock.On("AddSecondaryMemberships", ctx, teamName, members)}
+}
+
+func (_c *MockUserRepository_AddSecondaryMemberships_Call) Run(run func(ctx context.Context, teamName string, members []*entity.Member)) *MockUserRepository_AddSecondaryMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []*entity.Member
+		if args[2] != nil {
+			arg2 = args[2].([]*entity.Member)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddSecondaryMemberships_Call) Return(err error) *MockUserRepository_AddSecondaryMemberships_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddSecondaryMemberships_Call) RunAndReturn(run func(ctx context.Context, teamName string, members []*entity.Member) error) *MockUserRepository_AddSecondaryMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddUnavailability provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddUnavailability(ctx context.Context, unavailability *entity.Unavailability) error {
+	ret := _mock.Called(ctx, unavailability)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Unavailability) error); ok {
+		r0 = returnFunc(ctx, unavailability)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_AddUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUnavailability'
+type MockUserRepository_AddUnavailability_Call struct {
+	*mock.Call
+}
+
+// AddUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - unavailability *entity.Unavailability
+func (_e *MockUserRepository_Expecter) AddUnavailability(ctx interface{}, unavailability interface{}) *MockUserRepository_AddUnavailability_Call {
+	return &MockUserRepository_AddUnavailability_Call{Call: _e.mock.On("AddUnavailability", ctx, unavailability)}
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) Run(run func(ctx context.Context, unavailability *entity.Unavailability)) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Unavailability
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Unavailability)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) Return(err error) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) RunAndReturn(run func(ctx context.Context, unavailability *entity.Unavailability) error) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckUserExists provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) CheckUserExists(ctx context.Context, id string) (bool, error) {
 	ret := _mock.Called(ctx, id)
@@ -104,6 +225,137 @@ func (_c *MockUserRepository_CheckUserExists_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// ClaimStartedAbsence provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ClaimStartedAbsence(ctx context.Context, minDays int, skipIDs []int64) (*entity.Unavailability, error) {
+	ret := _mock.Called(ctx, minDays, skipIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimStartedAbsence")
+	}
+
+	var r0 *entity.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []int64) (*entity.Unavailability, error)); ok {
+		return returnFunc(ctx, minDays, skipIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []int64) *entity.Unavailability); ok {
+		r0 = returnFunc(ctx, minDays, skipIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, []int64) error); ok {
+		r1 = returnFunc(ctx, minDays, skipIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ClaimStartedAbsence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimStartedAbsence'
+type MockUserRepository_ClaimStartedAbsence_Call struct {
+	*mock.Call
+}
+
+// ClaimStartedAbsence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - minDays int
+//   - skipIDs []int64
+func (_e *MockUserRepository_Expecter) ClaimStartedAbsence(ctx interface{}, minDays interface{}, skipIDs interface{}) *MockUserRepository_ClaimStartedAbsence_Call {
+	return &MockUserRepository_ClaimStartedAbsence_Call{Call: _e.mock.On("ClaimStartedAbsence", ctx, minDays, skipIDs)}
+}
+
+func (_c *MockUserRepository_ClaimStartedAbsence_Call) Run(run func(ctx context.Context, minDays int, skipIDs []int64)) *MockUserRepository_ClaimStartedAbsence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []int64
+		if args[2] != nil {
+			arg2 = args[2].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ClaimStartedAbsence_Call) Return(unavailability *entity.Unavailability, err error) *MockUserRepository_ClaimStartedAbsence_Call {
+	_c.Call.Return(unavailability, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ClaimStartedAbsence_Call) RunAndReturn(run func(ctx context.Context, minDays int, skipIDs []int64) (*entity.Unavailability, error)) *MockUserRepository_ClaimStartedAbsence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Create(ctx context.Context, user *entity.User) error {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *MockUserRepository_Expecter) Create(ctx interface{}, user interface{}) *MockUserRepository_Create_Call {
+	return &MockUserRepository_Create_Call{Call: _e.mock.On("Create", ctx, user)}
+}
+
+func (_c *MockUserRepository_Create_Call) Run(run func(ctx context.Context, user *entity.User)) *MockUserRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.User
+		if args[1] != nil {
+			arg1 = args[1].(*entity.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Create_Call) Return(err error) *MockUserRepository_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(ctx context.Context, user *entity.User) error) *MockUserRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateBatch provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) CreateBatch(ctx context.Context, members []*entity.Member, teamName string) error {
 	ret := _mock.Called(ctx, members, teamName)
@@ -224,6 +476,84 @@ func (_c *MockUserRepository_DeactivateUsers_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// Delete provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Delete(ctx context.Context, id string, erase bool) (string, time.Time, error) {
+	ret := _mock.Called(ctx, id, erase)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 string
+	var r1 time.Time
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (string, time.Time, error)); ok {
+		return returnFunc(ctx, id, erase)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) string); ok {
+		r0 = returnFunc(ctx, id, erase)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) time.Time); ok {
+		r1 = returnFunc(ctx, id, erase)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = returnFunc(ctx, id, erase)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockUserRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - erase bool
+func (_e *MockUserRepository_Expecter) Delete(ctx interface{}, id interface{}, erase interface{}) *MockUserRepository_Delete_Call {
+	return &MockUserRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id, erase)}
+}
+
+func (_c *MockUserRepository_Delete_Call) Run(run func(ctx context.Context, id string, erase bool)) *MockUserRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) Return(s string, time1 time.Time, err error) *MockUserRepository_Delete_Call {
+	_c.Call.Return(s, time1, err)
+	return _c
+}
+
+func (_c *MockUserRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id string, erase bool) (string, time.Time, error)) *MockUserRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindExistingByIDs provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) FindExistingByIDs(ctx context.Context, ids []string) (map[string]struct{}, error) {
 	ret := _mock.Called(ctx, ids)
@@ -292,83 +622,163 @@ func (_c *MockUserRepository_FindExistingByIDs_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
-// GetActiveUsersIDsByTeamName provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetActiveUsersIDsByTeamName(ctx context.Context, teamName string, excludeIDs []string) ([]string, error) {
-	ret := _mock.Called(ctx, teamName, excludeIDs)
+// GetActiveUsersFromTeams provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetActiveUsersFromTeams(ctx context.Context, teamNames []string, excludeIDs []string, limit int) ([]*entity.User, error) {
+	ret := _mock.Called(ctx, teamNames, excludeIDs, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetActiveUsersIDsByTeamName")
+		panic("no return value specified for GetActiveUsersFromTeams")
 	}
 
-	var r0 []string
+	var r0 []*entity.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
-		return returnFunc(ctx, teamName, excludeIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, []string, int) ([]*entity.User, error)); ok {
+		return returnFunc(ctx, teamNames, excludeIDs, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
-		r0 = returnFunc(ctx, teamName, excludeIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, []string, int) []*entity.User); ok {
+		r0 = returnFunc(ctx, teamNames, excludeIDs, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*entity.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
-		r1 = returnFunc(ctx, teamName, excludeIDs)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, []string, int) error); ok {
+		r1 = returnFunc(ctx, teamNames, excludeIDs, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserRepository_GetActiveUsersIDsByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveUsersIDsByTeamName'
-type MockUserRepository_GetActiveUsersIDsByTeamName_Call struct {
+// MockUserRepository_GetActiveUsersFromTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveUsersFromTeams'
+type MockUserRepository_GetActiveUsersFromTeams_Call struct {
 	*mock.Call
 }
 
-// GetActiveUsersIDsByTeamName is a helper method to define mock.On call
+// GetActiveUsersFromTeams is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
+//   - teamNames []string
 //   - excludeIDs []string
-func (_e *MockUserRepository_Expecter) GetActiveUsersIDsByTeamName(ctx interface{}, teamName interface{}, excludeIDs interface{}) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
-	return &MockUserRepository_GetActiveUsersIDsByTeamName_Call{Call: _e.mock.On("GetActiveUsersIDsByTeamName", ctx, teamName, excludeIDs)}
+//   - limit int
+func (_e *MockUserRepository_Expecter) GetActiveUsersFromTeams(ctx interface{}, teamNames interface{}, excludeIDs interface{}, limit interface{}) *MockUserRepository_GetActiveUsersFromTeams_Call {
+	return &MockUserRepository_GetActiveUsersFromTeams_Call{Call: _e.mock.On("GetActiveUsersFromTeams", ctx, teamNames, excludeIDs, limit)}
 }
 
-func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) Run(run func(ctx context.Context, teamName string, excludeIDs []string)) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+func (_c *MockUserRepository_GetActiveUsersFromTeams_Call) Run(run func(ctx context.Context, teamNames []string, excludeIDs []string, limit int)) *MockUserRepository_GetActiveUsersFromTeams_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		var arg2 []string
 		if args[2] != nil {
 			arg2 = args[2].([]string)
 		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) Return(strings []string, err error) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockUserRepository_GetActiveUsersFromTeams_Call) Return(users []*entity.User, err error) *MockUserRepository_GetActiveUsersFromTeams_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeIDs []string) ([]string, error)) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+func (_c *MockUserRepository_GetActiveUsersFromTeams_Call) RunAndReturn(run func(ctx context.Context, teamNames []string, excludeIDs []string, limit int) ([]*entity.User, error)) *MockUserRepository_GetActiveUsersFromTeams_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllUsersIDs provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetAllUsersIDs(ctx context.Context) ([]string, error) {
-	ret := _mock.Called(ctx)
+// GetActiveUsersIDsByTeamName provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetActiveUsersIDsByTeamName(ctx context.Context, teamName string, excludeIDs []string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName, excludeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveUsersIDsByTeamName")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName, excludeIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetActiveUsersIDsByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveUsersIDsByTeamName'
+type MockUserRepository_GetActiveUsersIDsByTeamName_Call struct {
+	*mock.Call
+}
+
+// GetActiveUsersIDsByTeamName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - excludeIDs []string
+func (_e *MockUserRepository_Expecter) GetActiveUsersIDsByTeamName(ctx interface{}, teamName interface{}, excludeIDs interface{}) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+	return &MockUserRepository_GetActiveUsersIDsByTeamName_Call{Call: _e.mock.On("GetActiveUsersIDsByTeamName", ctx, teamName, excludeIDs)}
+}
+
+func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) Run(run func(ctx context.Context, teamName string, excludeIDs []string)) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) Return(strings []string, err error) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetActiveUsersIDsByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeIDs []string) ([]string, error)) *MockUserRepository_GetActiveUsersIDsByTeamName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllUsersIDs provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetAllUsersIDs(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllUsersIDs")
@@ -386,97 +796,946 @@ func (_mock *MockUserRepository) GetAllUsersIDs(ctx context.Context) ([]string,
 			r0 = ret.Get(0).([]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetAllUsersIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUsersIDs'
+type MockUserRepository_GetAllUsersIDs_Call struct {
+	*mock.Call
+}
+
+// GetAllUsersIDs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockUserRepository_Expecter) GetAllUsersIDs(ctx interface{}) *MockUserRepository_GetAllUsersIDs_Call {
+	return &MockUserRepository_GetAllUsersIDs_Call{Call: _e.mock.On("GetAllUsersIDs", ctx)}
+}
+
+func (_c *MockUserRepository_GetAllUsersIDs_Call) Run(run func(ctx context.Context)) *MockUserRepository_GetAllUsersIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetAllUsersIDs_Call) Return(strings []string, err error) *MockUserRepository_GetAllUsersIDs_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetAllUsersIDs_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockUserRepository_GetAllUsersIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAvailableMemberIDs provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetAvailableMemberIDs(ctx context.Context, teamName string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailableMemberIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetAvailableMemberIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailableMemberIDs'
+type MockUserRepository_GetAvailableMemberIDs_Call struct {
+	*mock.Call
+}
+
+// GetAvailableMemberIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockUserRepository_Expecter) GetAvailableMemberIDs(ctx interface{}, teamName interface{}) *MockUserRepository_GetAvailableMemberIDs_Call {
+	return &MockUserRepository_GetAvailableMemberIDs_Call{Call: _e.mock.On("GetAvailableMemberIDs", ctx, teamName)}
+}
+
+func (_c *MockUserRepository_GetAvailableMemberIDs_Call) Run(run func(ctx context.Context, teamName string)) *MockUserRepository_GetAvailableMemberIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetAvailableMemberIDs_Call) Return(strings []string, err error) *MockUserRepository_GetAvailableMemberIDs_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetAvailableMemberIDs_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]string, error)) *MockUserRepository_GetAvailableMemberIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByTeamName provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetByTeamName(ctx context.Context, teamName string) ([]*entity.Member, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByTeamName")
+	}
+
+	var r0 []*entity.Member
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Member, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.Member); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Member)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTeamName'
+type MockUserRepository_GetByTeamName_Call struct {
+	*mock.Call
+}
+
+// GetByTeamName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockUserRepository_Expecter) GetByTeamName(ctx interface{}, teamName interface{}) *MockUserRepository_GetByTeamName_Call {
+	return &MockUserRepository_GetByTeamName_Call{Call: _e.mock.On("GetByTeamName", ctx, teamName)}
+}
+
+func (_c *MockUserRepository_GetByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *MockUserRepository_GetByTeamName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByTeamName_Call) Return(members []*entity.Member, err error) *MockUserRepository_GetByTeamName_Call {
+	_c.Call.Return(members, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]*entity.Member, error)) *MockUserRepository_GetByTeamName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLeadIDs provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetLeadIDs(ctx context.Context, teamName string, excludeIDs []string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName, excludeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLeadIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName, excludeIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetLeadIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLeadIDs'
+type MockUserRepository_GetLeadIDs_Call struct {
+	*mock.Call
+}
+
+// GetLeadIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - excludeIDs []string
+func (_e *MockUserRepository_Expecter) GetLeadIDs(ctx interface{}, teamName interface{}, excludeIDs interface{}) *MockUserRepository_GetLeadIDs_Call {
+	return &MockUserRepository_GetLeadIDs_Call{Call: _e.mock.On("GetLeadIDs", ctx, teamName, excludeIDs)}
+}
+
+func (_c *MockUserRepository_GetLeadIDs_Call) Run(run func(ctx context.Context, teamName string, excludeIDs []string)) *MockUserRepository_GetLeadIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetLeadIDs_Call) Return(strings []string, err error) *MockUserRepository_GetLeadIDs_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetLeadIDs_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeIDs []string) ([]string, error)) *MockUserRepository_GetLeadIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReplacementReviewerForPullRequest provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, strategy string) (*entity.User, error) {
+	ret := _mock.Called(ctx, teamName, excludeUserIDs, strategy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReplacementReviewerForPullRequest")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, string) (*entity.User, error)); ok {
+		return returnFunc(ctx, teamName, excludeUserIDs, strategy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, string) *entity.User); ok {
+		r0 = returnFunc(ctx, teamName, excludeUserIDs, strategy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, string) error); ok {
+		r1 = returnFunc(ctx, teamName, excludeUserIDs, strategy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetReplacementReviewerForPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReplacementReviewerForPullRequest'
+type MockUserRepository_GetReplacementReviewerForPullRequest_Call struct {
+	*mock.Call
+}
+
+// GetReplacementReviewerForPullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - excludeUserIDs []string
+//   - strategy string
+func (_e *MockUserRepository_Expecter) GetReplacementReviewerForPullRequest(ctx interface{}, teamName interface{}, excludeUserIDs interface{}, strategy interface{}) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+	return &MockUserRepository_GetReplacementReviewerForPullRequest_Call{Call: _e.mock.On("GetReplacementReviewerForPullRequest", ctx, teamName, excludeUserIDs, strategy)}
+}
+
+func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) Run(run func(ctx context.Context, teamName string, excludeUserIDs []string, strategy string)) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) Return(user *entity.User, err error) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeUserIDs []string, strategy string) (*entity.User, error)) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewCapacities provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetReviewCapacities(ctx context.Context, ids []string) (map[string]int, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewCapacities")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]int, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]int); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetReviewCapacities_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewCapacities'
+type MockUserRepository_GetReviewCapacities_Call struct {
+	*mock.Call
+}
+
+// GetReviewCapacities is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockUserRepository_Expecter) GetReviewCapacities(ctx interface{}, ids interface{}) *MockUserRepository_GetReviewCapacities_Call {
+	return &MockUserRepository_GetReviewCapacities_Call{Call: _e.mock.On("GetReviewCapacities", ctx, ids)}
+}
+
+func (_c *MockUserRepository_GetReviewCapacities_Call) Run(run func(ctx context.Context, ids []string)) *MockUserRepository_GetReviewCapacities_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewCapacities_Call) Return(stringToInt map[string]int, err error) *MockUserRepository_GetReviewCapacities_Call {
+	_c.Call.Return(stringToInt, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewCapacities_Call) RunAndReturn(run func(ctx context.Context, ids []string) (map[string]int, error)) *MockUserRepository_GetReviewCapacities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewersForPullRequest provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetReviewersForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string, limit int, strategy string) ([]*entity.User, error) {
+	ret := _mock.Called(ctx, teamName, excludeUserIDs, limit, strategy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewersForPullRequest")
+	}
+
+	var r0 []*entity.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, int, string) ([]*entity.User, error)); ok {
+		return returnFunc(ctx, teamName, excludeUserIDs, limit, strategy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, int, string) []*entity.User); ok {
+		r0 = returnFunc(ctx, teamName, excludeUserIDs, limit, strategy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, int, string) error); ok {
+		r1 = returnFunc(ctx, teamName, excludeUserIDs, limit, strategy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetReviewersForPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewersForPullRequest'
+type MockUserRepository_GetReviewersForPullRequest_Call struct {
+	*mock.Call
+}
+
+// GetReviewersForPullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - excludeUserIDs []string
+//   - limit int
+//   - strategy string
+func (_e *MockUserRepository_Expecter) GetReviewersForPullRequest(ctx interface{}, teamName interface{}, excludeUserIDs interface{}, limit interface{}, strategy interface{}) *MockUserRepository_GetReviewersForPullRequest_Call {
+	return &MockUserRepository_GetReviewersForPullRequest_Call{Call: _e.mock.On("GetReviewersForPullRequest", ctx, teamName, excludeUserIDs, limit, strategy)}
+}
+
+func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Run(run func(ctx context.Context, teamName string, excludeUserIDs []string, limit int, strategy string)) *MockUserRepository_GetReviewersForPullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Return(users []*entity.User, err error) *MockUserRepository_GetReviewersForPullRequest_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewersForPullRequest_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeUserIDs []string, limit int, strategy string) ([]*entity.User, error)) *MockUserRepository_GetReviewersForPullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamMemberIDs provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetTeamMemberIDs(ctx context.Context, teamName string, ids []string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamMemberIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = returnFunc(ctx, teamName, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetTeamMemberIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamMemberIDs'
+type MockUserRepository_GetTeamMemberIDs_Call struct {
+	*mock.Call
+}
+
+// GetTeamMemberIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - ids []string
+func (_e *MockUserRepository_Expecter) GetTeamMemberIDs(ctx interface{}, teamName interface{}, ids interface{}) *MockUserRepository_GetTeamMemberIDs_Call {
+	return &MockUserRepository_GetTeamMemberIDs_Call{Call: _e.mock.On("GetTeamMemberIDs", ctx, teamName, ids)}
+}
+
+func (_c *MockUserRepository_GetTeamMemberIDs_Call) Run(run func(ctx context.Context, teamName string, ids []string)) *MockUserRepository_GetTeamMemberIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetTeamMemberIDs_Call) Return(strings []string, err error) *MockUserRepository_GetTeamMemberIDs_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetTeamMemberIDs_Call) RunAndReturn(run func(ctx context.Context, teamName string, ids []string) ([]string, error)) *MockUserRepository_GetTeamMemberIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUnavailabilityByUserID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUnavailabilityByUserID(ctx context.Context, userID string) ([]*entity.Unavailability, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUnavailabilityByUserID")
+	}
+
+	var r0 []*entity.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Unavailability, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.Unavailability); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUnavailabilityByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUnavailabilityByUserID'
+type MockUserRepository_GetUnavailabilityByUserID_Call struct {
+	*mock.Call
+}
+
+// GetUnavailabilityByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserRepository_Expecter) GetUnavailabilityByUserID(ctx interface{}, userID interface{}) *MockUserRepository_GetUnavailabilityByUserID_Call {
+	return &MockUserRepository_GetUnavailabilityByUserID_Call{Call: _e.mock.On("GetUnavailabilityByUserID", ctx, userID)}
+}
+
+func (_c *MockUserRepository_GetUnavailabilityByUserID_Call) Run(run func(ctx context.Context, userID string)) *MockUserRepository_GetUnavailabilityByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUnavailabilityByUserID_Call) Return(unavailabilitys []*entity.Unavailability, err error) *MockUserRepository_GetUnavailabilityByUserID_Call {
+	_c.Call.Return(unavailabilitys, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUnavailabilityByUserID_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]*entity.Unavailability, error)) *MockUserRepository_GetUnavailabilityByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *entity.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockUserRepository_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUserRepository_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockUserRepository_GetUserByID_Call {
+	return &MockUserRepository_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Run(run func(ctx context.Context, id string)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) Return(user *entity.User, err error) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entity.User, error)) *MockUserRepository_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUsersByIDs provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersByIDs")
+	}
+
+	var r0 []*entity.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entity.User, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entity.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUsersByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByIDs'
+type MockUserRepository_GetUsersByIDs_Call struct {
+	*mock.Call
+}
+
+// GetUsersByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockUserRepository_Expecter) GetUsersByIDs(ctx interface{}, ids interface{}) *MockUserRepository_GetUsersByIDs_Call {
+	return &MockUserRepository_GetUsersByIDs_Call{Call: _e.mock.On("GetUsersByIDs", ctx, ids)}
+}
+
+func (_c *MockUserRepository_GetUsersByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockUserRepository_GetUsersByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUsersByIDs_Call) Return(users []*entity.User, err error) *MockUserRepository_GetUsersByIDs_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUsersByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]*entity.User, error)) *MockUserRepository_GetUsersByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasCappedCandidates provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) HasCappedCandidates(ctx context.Context, teamName string, excludeIDs []string) (bool, error) {
+	ret := _mock.Called(ctx, teamName, excludeIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasCappedCandidates")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (bool, error)); ok {
+		return returnFunc(ctx, teamName, excludeIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) bool); ok {
+		r0 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, excludeIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_HasCappedCandidates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasCappedCandidates'
+type MockUserRepository_HasCappedCandidates_Call struct {
+	*mock.Call
+}
+
+// HasCappedCandidates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - excludeIDs []string
+func (_e *MockUserRepository_Expecter) HasCappedCandidates(ctx interface{}, teamName interface{}, excludeIDs interface{}) *MockUserRepository_HasCappedCandidates_Call {
+	return &MockUserRepository_HasCappedCandidates_Call{Call: _e.mock.On("HasCappedCandidates", ctx, teamName, excludeIDs)}
+}
+
+func (_c *MockUserRepository_HasCappedCandidates_Call) Run(run func(ctx context.Context, teamName string, excludeIDs []string)) *MockUserRepository_HasCappedCandidates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_HasCappedCandidates_Call) Return(b bool, err error) *MockUserRepository_HasCappedCandidates_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_HasCappedCandidates_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeIDs []string) (bool, error)) *MockUserRepository_HasCappedCandidates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsUnavailable provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) IsUnavailable(ctx context.Context, userID string) (bool, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUnavailable")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserRepository_GetAllUsersIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllUsersIDs'
-type MockUserRepository_GetAllUsersIDs_Call struct {
+// MockUserRepository_IsUnavailable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsUnavailable'
+type MockUserRepository_IsUnavailable_Call struct {
 	*mock.Call
 }
 
-// GetAllUsersIDs is a helper method to define mock.On call
+// IsUnavailable is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockUserRepository_Expecter) GetAllUsersIDs(ctx interface{}) *MockUserRepository_GetAllUsersIDs_Call {
-	return &MockUserRepository_GetAllUsersIDs_Call{Call: _e.mock.On("GetAllUsersIDs", ctx)}
+//   - userID string
+func (_e *MockUserRepository_Expecter) IsUnavailable(ctx interface{}, userID interface{}) *MockUserRepository_IsUnavailable_Call {
+	return &MockUserRepository_IsUnavailable_Call{Call: _e.mock.On("IsUnavailable", ctx, userID)}
 }
 
-func (_c *MockUserRepository_GetAllUsersIDs_Call) Run(run func(ctx context.Context)) *MockUserRepository_GetAllUsersIDs_Call {
+func (_c *MockUserRepository_IsUnavailable_Call) Run(run func(ctx context.Context, userID string)) *MockUserRepository_IsUnavailable_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_GetAllUsersIDs_Call) Return(strings []string, err error) *MockUserRepository_GetAllUsersIDs_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockUserRepository_IsUnavailable_Call) Return(b bool, err error) *MockUserRepository_IsUnavailable_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetAllUsersIDs_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *MockUserRepository_GetAllUsersIDs_Call {
+func (_c *MockUserRepository_IsUnavailable_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *MockUserRepository_IsUnavailable_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetByTeamName provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetByTeamName(ctx context.Context, teamName string) ([]*entity.Member, error) {
-	ret := _mock.Called(ctx, teamName)
+// MarkAbsencesHandled provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) MarkAbsencesHandled(ctx context.Context, ids []int64) error {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetByTeamName")
+		panic("no return value specified for MarkAbsencesHandled")
 	}
 
-	var r0 []*entity.Member
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Member, error)); ok {
-		return returnFunc(ctx, teamName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*entity.Member); ok {
-		r0 = returnFunc(ctx, teamName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Member)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUserRepository_GetByTeamName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByTeamName'
-type MockUserRepository_GetByTeamName_Call struct {
+// MockUserRepository_MarkAbsencesHandled_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAbsencesHandled'
+type MockUserRepository_MarkAbsencesHandled_Call struct {
 	*mock.Call
 }
 
-// GetByTeamName is a helper method to define mock.On call
+// MarkAbsencesHandled is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *MockUserRepository_Expecter) GetByTeamName(ctx interface{}, teamName interface{}) *MockUserRepository_GetByTeamName_Call {
-	return &MockUserRepository_GetByTeamName_Call{Call: _e.mock.On("GetByTeamName", ctx, teamName)}
+//   - ids []int64
+func (_e *MockUserRepository_Expecter) MarkAbsencesHandled(ctx interface{}, ids interface{}) *MockUserRepository_MarkAbsencesHandled_Call {
+	return &MockUserRepository_MarkAbsencesHandled_Call{Call: _e.mock.On("MarkAbsencesHandled", ctx, ids)}
 }
 
-func (_c *MockUserRepository_GetByTeamName_Call) Run(run func(ctx context.Context, teamName string)) *MockUserRepository_GetByTeamName_Call {
+func (_c *MockUserRepository_MarkAbsencesHandled_Call) Run(run func(ctx context.Context, ids []int64)) *MockUserRepository_MarkAbsencesHandled_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]int64)
 		}
 		run(
 			arg0,
@@ -486,58 +1745,47 @@ func (_c *MockUserRepository_GetByTeamName_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockUserRepository_GetByTeamName_Call) Return(members []*entity.Member, err error) *MockUserRepository_GetByTeamName_Call {
-	_c.Call.Return(members, err)
+func (_c *MockUserRepository_MarkAbsencesHandled_Call) Return(err error) *MockUserRepository_MarkAbsencesHandled_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetByTeamName_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]*entity.Member, error)) *MockUserRepository_GetByTeamName_Call {
+func (_c *MockUserRepository_MarkAbsencesHandled_Call) RunAndReturn(run func(ctx context.Context, ids []int64) error) *MockUserRepository_MarkAbsencesHandled_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReplacementReviewerForPullRequest provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetReplacementReviewerForPullRequest(ctx context.Context, teamName string, excludeUserIDs []string) (*entity.User, error) {
-	ret := _mock.Called(ctx, teamName, excludeUserIDs)
+// RemoveFromTeam provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RemoveFromTeam(ctx context.Context, teamName string, ids []string) error {
+	ret := _mock.Called(ctx, teamName, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReplacementReviewerForPullRequest")
+		panic("no return value specified for RemoveFromTeam")
 	}
 
-	var r0 *entity.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (*entity.User, error)); ok {
-		return returnFunc(ctx, teamName, excludeUserIDs)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) *entity.User); ok {
-		r0 = returnFunc(ctx, teamName, excludeUserIDs)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
-		r1 = returnFunc(ctx, teamName, excludeUserIDs)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, teamName, ids)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUserRepository_GetReplacementReviewerForPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReplacementReviewerForPullRequest'
-type MockUserRepository_GetReplacementReviewerForPullRequest_Call struct {
+// MockUserRepository_RemoveFromTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveFromTeam'
+type MockUserRepository_RemoveFromTeam_Call struct {
 	*mock.Call
 }
 
-// GetReplacementReviewerForPullRequest is a helper method to define mock.On call
+// RemoveFromTeam is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
-//   - excludeUserIDs []string
-func (_e *MockUserRepository_Expecter) GetReplacementReviewerForPullRequest(ctx interface{}, teamName interface{}, excludeUserIDs interface{}) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
-	return &MockUserRepository_GetReplacementReviewerForPullRequest_Call{Call: _e.mock.On("GetReplacementReviewerForPullRequest", ctx, teamName, excludeUserIDs)}
+//   - ids []string
+func (_e *MockUserRepository_Expecter) RemoveFromTeam(ctx interface{}, teamName interface{}, ids interface{}) *MockUserRepository_RemoveFromTeam_Call {
+	return &MockUserRepository_RemoveFromTeam_Call{Call: _e.mock.On("RemoveFromTeam", ctx, teamName, ids)}
 }
 
-func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) Run(run func(ctx context.Context, teamName string, excludeUserIDs []string)) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+func (_c *MockUserRepository_RemoveFromTeam_Call) Run(run func(ctx context.Context, teamName string, ids []string)) *MockUserRepository_RemoveFromTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -560,58 +1808,47 @@ func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) Run(run
 	return _c
 }
 
-func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) Return(user *entity.User, err error) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
-	_c.Call.Return(user, err)
+func (_c *MockUserRepository_RemoveFromTeam_Call) Return(err error) *MockUserRepository_RemoveFromTeam_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetReplacementReviewerForPullRequest_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeUserIDs []string) (*entity.User, error)) *MockUserRepository_GetReplacementReviewerForPullRequest_Call {
+func (_c *MockUserRepository_RemoveFromTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, ids []string) error) *MockUserRepository_RemoveFromTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReviewersForPullRequest provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetReviewersForPullRequest(ctx context.Context, teamName string, excludeUserID string) ([]*entity.User, error) {
-	ret := _mock.Called(ctx, teamName, excludeUserID)
+// RemoveUnavailability provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RemoveUnavailability(ctx context.Context, userID string, id int64) error {
+	ret := _mock.Called(ctx, userID, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReviewersForPullRequest")
+		panic("no return value specified for RemoveUnavailability")
 	}
 
-	var r0 []*entity.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*entity.User, error)); ok {
-		return returnFunc(ctx, teamName, excludeUserID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*entity.User); ok {
-		r0 = returnFunc(ctx, teamName, excludeUserID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, teamName, excludeUserID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, userID, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUserRepository_GetReviewersForPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewersForPullRequest'
-type MockUserRepository_GetReviewersForPullRequest_Call struct {
+// MockUserRepository_RemoveUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveUnavailability'
+type MockUserRepository_RemoveUnavailability_Call struct {
 	*mock.Call
 }
 
-// GetReviewersForPullRequest is a helper method to define mock.On call
+// RemoveUnavailability is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-//   - excludeUserID string
-func (_e *MockUserRepository_Expecter) GetReviewersForPullRequest(ctx interface{}, teamName interface{}, excludeUserID interface{}) *MockUserRepository_GetReviewersForPullRequest_Call {
-	return &MockUserRepository_GetReviewersForPullRequest_Call{Call: _e.mock.On("GetReviewersForPullRequest", ctx, teamName, excludeUserID)}
+//   - userID string
+//   - id int64
+func (_e *MockUserRepository_Expecter) RemoveUnavailability(ctx interface{}, userID interface{}, id interface{}) *MockUserRepository_RemoveUnavailability_Call {
+	return &MockUserRepository_RemoveUnavailability_Call{Call: _e.mock.On("RemoveUnavailability", ctx, userID, id)}
 }
 
-func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Run(run func(ctx context.Context, teamName string, excludeUserID string)) *MockUserRepository_GetReviewersForPullRequest_Call {
+func (_c *MockUserRepository_RemoveUnavailability_Call) Run(run func(ctx context.Context, userID string, id int64)) *MockUserRepository_RemoveUnavailability_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -621,9 +1858,9 @@ func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Run(run func(ctx c
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 int64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
@@ -634,65 +1871,54 @@ func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Run(run func(ctx c
 	return _c
 }
 
-func (_c *MockUserRepository_GetReviewersForPullRequest_Call) Return(users []*entity.User, err error) *MockUserRepository_GetReviewersForPullRequest_Call {
-	_c.Call.Return(users, err)
+func (_c *MockUserRepository_RemoveUnavailability_Call) Return(err error) *MockUserRepository_RemoveUnavailability_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetReviewersForPullRequest_Call) RunAndReturn(run func(ctx context.Context, teamName string, excludeUserID string) ([]*entity.User, error)) *MockUserRepository_GetReviewersForPullRequest_Call {
+func (_c *MockUserRepository_RemoveUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string, id int64) error) *MockUserRepository_RemoveUnavailability_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByID provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetUserByID(ctx context.Context, id string) (*entity.User, error) {
-	ret := _mock.Called(ctx, id)
+// SaveDeactivationPlan provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SaveDeactivationPlan(ctx context.Context, plan *entity.DeactivationPlan) error {
+	ret := _mock.Called(ctx, plan)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByID")
+		panic("no return value specified for SaveDeactivationPlan")
 	}
 
-	var r0 *entity.User
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entity.User)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.DeactivationPlan) error); ok {
+		r0 = returnFunc(ctx, plan)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockUserRepository_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
-type MockUserRepository_GetUserByID_Call struct {
+// MockUserRepository_SaveDeactivationPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveDeactivationPlan'
+type MockUserRepository_SaveDeactivationPlan_Call struct {
 	*mock.Call
 }
 
-// GetUserByID is a helper method to define mock.On call
+// SaveDeactivationPlan is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockUserRepository_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockUserRepository_GetUserByID_Call {
-	return &MockUserRepository_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+//   - plan *entity.DeactivationPlan
+func (_e *MockUserRepository_Expecter) SaveDeactivationPlan(ctx interface{}, plan interface{}) *MockUserRepository_SaveDeactivationPlan_Call {
+	return &MockUserRepository_SaveDeactivationPlan_Call{Call: _e.mock.On("SaveDeactivationPlan", ctx, plan)}
 }
 
-func (_c *MockUserRepository_GetUserByID_Call) Run(run func(ctx context.Context, id string)) *MockUserRepository_GetUserByID_Call {
+func (_c *MockUserRepository_SaveDeactivationPlan_Call) Run(run func(ctx context.Context, plan *entity.DeactivationPlan)) *MockUserRepository_SaveDeactivationPlan_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *entity.DeactivationPlan
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(*entity.DeactivationPlan)
 		}
 		run(
 			arg0,
@@ -702,80 +1928,92 @@ func (_c *MockUserRepository_GetUserByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockUserRepository_GetUserByID_Call) Return(user *entity.User, err error) *MockUserRepository_GetUserByID_Call {
-	_c.Call.Return(user, err)
+func (_c *MockUserRepository_SaveDeactivationPlan_Call) Return(err error) *MockUserRepository_SaveDeactivationPlan_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*entity.User, error)) *MockUserRepository_GetUserByID_Call {
+func (_c *MockUserRepository_SaveDeactivationPlan_Call) RunAndReturn(run func(ctx context.Context, plan *entity.DeactivationPlan) error) *MockUserRepository_SaveDeactivationPlan_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsersByIDs provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) GetUsersByIDs(ctx context.Context, ids []string) ([]*entity.User, error) {
-	ret := _mock.Called(ctx, ids)
+// Search provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Search(ctx context.Context, query string, limit int, offset int) ([]*entity.User, error) {
+	ret := _mock.Called(ctx, query, limit, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUsersByIDs")
+		panic("no return value specified for Search")
 	}
 
 	var r0 []*entity.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*entity.User, error)); ok {
-		return returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*entity.User, error)); ok {
+		return returnFunc(ctx, query, limit, offset)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*entity.User); ok {
-		r0 = returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) []*entity.User); ok {
+		r0 = returnFunc(ctx, query, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entity.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, query, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserRepository_GetUsersByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsersByIDs'
-type MockUserRepository_GetUsersByIDs_Call struct {
+// MockUserRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockUserRepository_Search_Call struct {
 	*mock.Call
 }
 
-// GetUsersByIDs is a helper method to define mock.On call
+// Search is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ids []string
-func (_e *MockUserRepository_Expecter) GetUsersByIDs(ctx interface{}, ids interface{}) *MockUserRepository_GetUsersByIDs_Call {
-	return &MockUserRepository_GetUsersByIDs_Call{Call: _e.mock.On("GetUsersByIDs", ctx, ids)}
+//   - query string
+//   - limit int
+//   - offset int
+func (_e *MockUserRepository_Expecter) Search(ctx interface{}, query interface{}, limit interface{}, offset interface{}) *MockUserRepository_Search_Call {
+	return &MockUserRepository_Search_Call{Call: _e.mock.On("Search", ctx, query, limit, offset)}
 }
 
-func (_c *MockUserRepository_GetUsersByIDs_Call) Run(run func(ctx context.Context, ids []string)) *MockUserRepository_GetUsersByIDs_Call {
+func (_c *MockUserRepository_Search_Call) Run(run func(ctx context.Context, query string, limit int, offset int)) *MockUserRepository_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_GetUsersByIDs_Call) Return(users []*entity.User, err error) *MockUserRepository_GetUsersByIDs_Call {
+func (_c *MockUserRepository_Search_Call) Return(users []*entity.User, err error) *MockUserRepository_Search_Call {
 	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockUserRepository_GetUsersByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []string) ([]*entity.User, error)) *MockUserRepository_GetUsersByIDs_Call {
+func (_c *MockUserRepository_Search_Call) RunAndReturn(run func(ctx context.Context, query string, limit int, offset int) ([]*entity.User, error)) *MockUserRepository_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -843,6 +2081,74 @@ func (_c *MockUserRepository_SetIsActive_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// TakeDeactivationPlan provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) TakeDeactivationPlan(ctx context.Context, token string) (*entity.DeactivationPlan, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TakeDeactivationPlan")
+	}
+
+	var r0 *entity.DeactivationPlan
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*entity.DeactivationPlan, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *entity.DeactivationPlan); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.DeactivationPlan)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_TakeDeactivationPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TakeDeactivationPlan'
+type MockUserRepository_TakeDeactivationPlan_Call struct {
+	*mock.Call
+}
+
+// TakeDeactivationPlan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token string
+func (_e *MockUserRepository_Expecter) TakeDeactivationPlan(ctx interface{}, token interface{}) *MockUserRepository_TakeDeactivationPlan_Call {
+	return &MockUserRepository_TakeDeactivationPlan_Call{Call: _e.mock.On("TakeDeactivationPlan", ctx, token)}
+}
+
+func (_c *MockUserRepository_TakeDeactivationPlan_Call) Run(run func(ctx context.Context, token string)) *MockUserRepository_TakeDeactivationPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_TakeDeactivationPlan_Call) Return(deactivationPlan *entity.DeactivationPlan, err error) *MockUserRepository_TakeDeactivationPlan_Call {
+	_c.Call.Return(deactivationPlan, err)
+	return _c
+}
+
+func (_c *MockUserRepository_TakeDeactivationPlan_Call) RunAndReturn(run func(ctx context.Context, token string) (*entity.DeactivationPlan, error)) *MockUserRepository_TakeDeactivationPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateMembers provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) UpdateMembers(ctx context.Context, members []*entity.Member, teamName string) error {
 	ret := _mock.Called(ctx, members, teamName)
@@ -905,3 +2211,60 @@ func (_c *MockUserRepository_UpdateMembers_Call) RunAndReturn(run func(ctx conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpdateProfile provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) UpdateProfile(ctx context.Context, user *entity.User) error {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type MockUserRepository_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *entity.User
+func (_e *MockUserRepository_Expecter) UpdateProfile(ctx interface{}, user interface{}) *MockUserRepository_UpdateProfile_Call {
+	return &MockUserRepository_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, user)}
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Run(run func(ctx context.Context, user *entity.User)) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.User
+		if args[1] != nil {
+			arg1 = args[1].(*entity.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) Return(err error) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, user *entity.User) error) *MockUserRepository_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
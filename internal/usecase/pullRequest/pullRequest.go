@@ -7,7 +7,9 @@ import (
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -16,19 +18,33 @@ type UseCase struct {
 	userRepo        usecase.UserRepository
 	teamRepo        usecase.TeamRepository
 	transactor      trm.Manager
+	fallbackDepth   int
 }
 
-func NewUseCase(pullRequestRepo usecase.PullRequestRepository, userRepo usecase.UserRepository, teamRepo usecase.TeamRepository, transactor trm.Manager) *UseCase {
+func NewUseCase(
+	pullRequestRepo usecase.PullRequestRepository,
+	userRepo usecase.UserRepository,
+	teamRepo usecase.TeamRepository,
+	transactor trm.Manager,
+	fallbackDepth int,
+) *UseCase {
 	return &UseCase{
 		pullRequestRepo: pullRequestRepo,
 		teamRepo:        teamRepo,
 		userRepo:        userRepo,
 		transactor:      transactor,
+		fallbackDepth:   fallbackDepth,
 	}
 }
 
 func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullRequestRequest) (*entity.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "pullRequest.CreatePullRequest",
+		attribute.String("pull_request.id", pr.PullRequestID),
+		attribute.String("pull_request.author_id", pr.AuthorID),
+	)
+	defer span.End()
 	l := logger.FromCtx(ctx)
+	var crossTeamReviewers []string
 	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
 		exists, err := uc.pullRequestRepo.CheckPullRequestIDExists(ctx, pr.PullRequestID)
 		if err != nil {
@@ -36,7 +52,7 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullR
 			return err
 		}
 		if exists {
-			return entity.ErrAlreadyExists
+			return entity.ErrPRExists
 		}
 
 		author, err := uc.userRepo.GetUserByID(ctx, pr.AuthorID)
@@ -44,16 +60,54 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullR
 			l.Warn("failed to get author by ID", zap.Error(err))
 			return err
 		}
+		if author.DeletedAt != nil {
+			return entity.ErrUserNotFound
+		}
 
-		reviewers, err := uc.userRepo.GetReviewersForPullRequest(ctx, author.TeamName, author.ID)
+		archived, err := uc.teamRepo.IsArchived(ctx, author.TeamName)
 		if err != nil {
-			l.Warn("failed to get reviewers by ID", zap.Error(err))
+			l.Warn("failed to check if author team is archived", zap.Error(err))
 			return err
 		}
+		if archived {
+			return entity.ErrTeamArchived
+		}
 
-		reviewersIDs := make([]string, 0, len(reviewers))
-		for _, reviewer := range reviewers {
-			reviewersIDs = append(reviewersIDs, reviewer.ID)
+		settings, err := uc.getTeamSettings(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		if len(pr.ReviewerIDs) > 0 {
+			if err = uc.validateChosenReviewers(ctx, settings, author.ID, pr.ReviewerIDs); err != nil {
+				return err
+			}
+		}
+
+		reviewersIDs := append([]string(nil), pr.ReviewerIDs...)
+		excludeIDs := append([]string{author.ID}, pr.ReviewerIDs...)
+
+		if need := settings.ReviewerCount - len(reviewersIDs); need > 0 {
+			reviewers, err := uc.userRepo.GetReviewersForPullRequest(ctx, author.TeamName, excludeIDs, need, settings.SelectionStrategy)
+			if err != nil {
+				l.Warn("failed to get reviewers by ID", zap.Error(err))
+				return err
+			}
+			for _, reviewer := range reviewers {
+				reviewersIDs = append(reviewersIDs, reviewer.ID)
+				excludeIDs = append(excludeIDs, reviewer.ID)
+			}
+		}
+
+		if need := settings.ReviewerCount - len(reviewersIDs); need > 0 && settings.CrossTeamFallback {
+			borrowed, err := uc.pickFallbackReviewers(ctx, author.TeamName, excludeIDs, need)
+			if err != nil {
+				return err
+			}
+			for _, reviewer := range borrowed {
+				reviewersIDs = append(reviewersIDs, reviewer.ID)
+				crossTeamReviewers = append(crossTeamReviewers, reviewer.ID)
+			}
 		}
 
 		newPR := &entity.PullRequest{
@@ -65,6 +119,9 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullR
 
 		if err = uc.pullRequestRepo.Create(ctx, newPR); err != nil {
 			l.Warn("failed to create pull request", zap.Error(err))
+			if errors.Is(err, entity.ErrAlreadyExists) {
+				return entity.ErrPRExists
+			}
 			return err
 		}
 
@@ -75,11 +132,21 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullR
 			}
 		}
 
+		// The backlog is filled from the author's team, so PRs of authors
+		// without one are not queued.
+		if missing := settings.ReviewerCount - len(reviewersIDs); missing > 0 && author.TeamName != "" {
+			if err = uc.pullRequestRepo.EnqueueAwaitingReviewers(ctx, pr.PullRequestID, missing); err != nil {
+				l.Warn("failed to queue pull request", zap.Error(err))
+				return err
+			}
+		}
+
 		return nil
 	})
 
 	if err != nil {
 		l.Warn("failed to create pull request", zap.Error(err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
@@ -95,11 +162,14 @@ func (uc *UseCase) CreatePullRequest(ctx context.Context, pr *entity.CreatePullR
 		return nil, err
 	}
 	createdPR.Reviewers = createdReviewers
+	createdPR.CrossTeamReviewers = crossTeamReviewers
 
 	return createdPR, nil
 }
 
 func (uc *UseCase) MergePullRequest(ctx context.Context, pr *entity.MergePullRequestRequest) (*entity.PullRequest, error) {
+	ctx, span := tracing.Start(ctx, "pullRequest.MergePullRequest", attribute.String("pull_request.id", pr.PullRequestID))
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	exists, err := uc.pullRequestRepo.CheckPullRequestIDExists(ctx, pr.PullRequestID)
 	if err != nil {
@@ -134,8 +204,14 @@ func (uc *UseCase) MergePullRequest(ctx context.Context, pr *entity.MergePullReq
 }
 
 func (uc *UseCase) ReassignPullRequest(ctx context.Context, pr *entity.ReassignPullRequestRequest) (*entity.ReassignPullRequestResponse, error) {
+	ctx, span := tracing.Start(ctx, "pullRequest.ReassignPullRequest",
+		attribute.String("pull_request.id", pr.PullRequestID),
+		attribute.String("pull_request.old_reviewer_id", pr.OldUserID),
+	)
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	var replacedBy string
+	var escalated bool
 	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
 		pullRequest, err := uc.pullRequestRepo.GetPullRequestByID(ctx, pr.PullRequestID)
 		if err != nil {
@@ -174,24 +250,53 @@ func (uc *UseCase) ReassignPullRequest(ctx context.Context, pr *entity.ReassignP
 		excludedIDs = append(excludedIDs, reviewers...)
 		excludedIDs = append(excludedIDs, pullRequest.AuthorID)
 
-		newReviewer, err := uc.userRepo.GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, excludedIDs)
+		settings, err := uc.getTeamSettings(ctx, oldUser.TeamName)
 		if err != nil {
-			if errors.Is(err, entity.ErrNotFound) {
-				return entity.ErrNoCandidate
+			return err
+		}
+
+		newReviewer, err := uc.userRepo.GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, excludedIDs, settings.SelectionStrategy)
+		switch {
+		case err == nil:
+			replacedBy = newReviewer.ID
+		case errors.Is(err, entity.ErrNotFound):
+			replacedBy, escalated, err = uc.pickLastResortReviewer(ctx, settings, excludedIDs)
+			if errors.Is(err, entity.ErrNoCandidate) {
+				// Rather than failing, the review is dropped and the PR waits
+				// for a teammate whose load goes down.
+				capped, capErr := uc.userRepo.HasCappedCandidates(ctx, oldUser.TeamName, excludedIDs)
+				if capErr != nil {
+					l.Warn("failed to check capped candidates", zap.Error(capErr))
+					return capErr
+				}
+				if !capped {
+					return err
+				}
+				err = nil
+			}
+			if err != nil {
+				return err
 			}
+		default:
 			l.Warn("failed to get new reviewer", zap.Error(err))
 			return err
 		}
 
-		replacedBy = newReviewer.ID
-
 		err = uc.pullRequestRepo.RemoveReviewer(ctx, pr.PullRequestID, oldUser.ID)
 		if err != nil {
 			l.Warn("failed to remove old reviewer", zap.Error(err))
 			return err
 		}
 
-		err = uc.pullRequestRepo.AddNewReviewer(ctx, pr.PullRequestID, newReviewer.ID)
+		if replacedBy == "" {
+			if err = uc.pullRequestRepo.EnqueueAwaitingReviewers(ctx, pr.PullRequestID, 1); err != nil {
+				l.Warn("failed to queue pull request", zap.Error(err))
+				return err
+			}
+			return nil
+		}
+
+		err = uc.pullRequestRepo.AddNewReviewer(ctx, pr.PullRequestID, replacedBy)
 		if err != nil {
 			l.Warn("failed to assign new reviewer", zap.Error(err))
 			return err
@@ -200,6 +305,7 @@ func (uc *UseCase) ReassignPullRequest(ctx context.Context, pr *entity.ReassignP
 	})
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
@@ -216,6 +322,11 @@ func (uc *UseCase) ReassignPullRequest(ctx context.Context, pr *entity.ReassignP
 	}
 	updatedPR.Reviewers = updatedReviewers
 
+	updatedPR.CrossTeamReviewers, err = uc.getCrossTeamReviewers(ctx, updatedPR.AuthorID, updatedReviewers)
+	if err != nil {
+		return nil, err
+	}
+
 	pullRequestResponse := &entity.PullRequestResponse{
 		PR: updatedPR,
 	}
@@ -223,7 +334,240 @@ func (uc *UseCase) ReassignPullRequest(ctx context.Context, pr *entity.ReassignP
 	result := &entity.ReassignPullRequestResponse{
 		PullRequestResponse: pullRequestResponse,
 		ReplacedBy:          replacedBy,
+		Escalated:           escalated,
 	}
 
 	return result, nil
 }
+
+// GetBacklog returns open PRs waiting for reviewers, optionally only those of
+// teamName.
+func (uc *UseCase) GetBacklog(ctx context.Context, teamName string) (*entity.BacklogResponse, error) {
+	ctx, span := tracing.Start(ctx, "pullRequest.GetBacklog", attribute.String("team.name", teamName))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	if teamName != "" {
+		exists, err := uc.teamRepo.CheckTeamNameExists(ctx, teamName)
+		if err != nil {
+			l.Warn("failed to check team existence", zap.Error(err))
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if !exists {
+			return nil, entity.ErrTeamNotFound
+		}
+	}
+
+	backlog, err := uc.pullRequestRepo.GetBacklog(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get backlog", zap.Error(err))
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return &entity.BacklogResponse{PullRequests: backlog}, nil
+}
+
+// getTeamSettings loads the reviewer settings of teamName. Users without a
+// team get the defaults.
+func (uc *UseCase) getTeamSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	l := logger.FromCtx(ctx)
+
+	settings, err := uc.teamRepo.GetSettings(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return entity.DefaultTeamSettings(teamName), nil
+		}
+		l.Warn("failed to get team settings", zap.Error(err))
+		return nil, err
+	}
+	return settings, nil
+}
+
+// validateChosenReviewers checks reviewers picked by the author: the team must
+// allow it, and they must be active members of the author's team.
+func (uc *UseCase) validateChosenReviewers(ctx context.Context, settings *entity.TeamSettings, authorID string, reviewerIDs []string) error {
+	l := logger.FromCtx(ctx)
+
+	if !settings.AuthorChoosesReviewers {
+		return entity.ErrReviewerChoiceOff
+	}
+	if len(reviewerIDs) > settings.ReviewerCount {
+		return entity.ErrTooManyReviewers
+	}
+
+	users, err := uc.userRepo.GetUsersByIDs(ctx, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get chosen reviewers", zap.Error(err))
+		return err
+	}
+	if len(users) != len(reviewerIDs) {
+		return entity.ErrInvalidReviewers
+	}
+	for _, user := range users {
+		if user.ID == authorID || !user.IsActive {
+			return entity.ErrInvalidReviewers
+		}
+	}
+
+	memberIDs, err := uc.userRepo.GetTeamMemberIDs(ctx, settings.TeamName, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get team member IDs", zap.Error(err))
+		return err
+	}
+	if len(memberIDs) != len(reviewerIDs) {
+		return entity.ErrInvalidReviewers
+	}
+	return nil
+}
+
+// pickLastResortReviewer is used when the team has no replacement of its own:
+// it borrows a reviewer from related teams if the team settings allow it, then
+// escalates to a team lead if the team allows it, and fails with
+// ErrNoCandidate otherwise.
+func (uc *UseCase) pickLastResortReviewer(ctx context.Context, settings *entity.TeamSettings, excludeIDs []string) (string, bool, error) {
+	l := logger.FromCtx(ctx)
+	teamName := settings.TeamName
+
+	if settings.CrossTeamFallback {
+		borrowed, err := uc.pickFallbackReviewers(ctx, teamName, excludeIDs, 1)
+		if err != nil {
+			return "", false, err
+		}
+		if len(borrowed) > 0 {
+			return borrowed[0].ID, false, nil
+		}
+	}
+
+	enabled, err := uc.teamRepo.IsLeadEscalationEnabled(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check lead escalation", zap.Error(err))
+		return "", false, err
+	}
+	if !enabled {
+		return "", false, entity.ErrNoCandidate
+	}
+
+	leadIDs, err := uc.userRepo.GetLeadIDs(ctx, teamName, excludeIDs)
+	if err != nil {
+		l.Warn("failed to get team leads", zap.Error(err))
+		return "", false, err
+	}
+	if len(leadIDs) == 0 {
+		return "", false, entity.ErrNoCandidate
+	}
+
+	return leadIDs[0], true, nil
+}
+
+// fallbackTiers returns groups of teams to borrow reviewers from, in order:
+// sibling teams, then the parent team, limited by the configured depth.
+func (uc *UseCase) fallbackTiers(ctx context.Context, teamName string) ([][]string, error) {
+	l := logger.FromCtx(ctx)
+	if uc.fallbackDepth == 0 || teamName == "" {
+		return nil, nil
+	}
+
+	parent, err := uc.teamRepo.GetParentName(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get parent team", zap.Error(err))
+		return nil, err
+	}
+	if parent == "" {
+		return nil, nil
+	}
+
+	children, err := uc.teamRepo.GetChildNames(ctx, parent)
+	if err != nil {
+		l.Warn("failed to get sibling teams", zap.Error(err))
+		return nil, err
+	}
+
+	siblings := make([]string, 0, len(children))
+	for _, child := range children {
+		if child != teamName {
+			siblings = append(siblings, child)
+		}
+	}
+
+	tiers := [][]string{siblings}
+	if uc.fallbackDepth > 1 {
+		tiers = append(tiers, []string{parent})
+	}
+	return tiers, nil
+}
+
+// pickFallbackReviewers borrows up to need active users from teams related to
+// teamName when it has too few candidates of its own.
+func (uc *UseCase) pickFallbackReviewers(ctx context.Context, teamName string, excludeIDs []string, need int) ([]*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "pullRequest.pickFallbackReviewers",
+		attribute.String("team.name", teamName),
+		attribute.Int("reviewers.needed", need),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	tiers, err := uc.fallbackTiers(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	picked := make([]*entity.User, 0, need)
+	exclude := append([]string(nil), excludeIDs...)
+	for _, tier := range tiers {
+		if len(picked) == need {
+			break
+		}
+		if len(tier) == 0 {
+			continue
+		}
+
+		users, err := uc.userRepo.GetActiveUsersFromTeams(ctx, tier, exclude, need-len(picked))
+		if err != nil {
+			l.Warn("failed to get fallback reviewers", zap.Error(err))
+			return nil, err
+		}
+		for _, user := range users {
+			picked = append(picked, user)
+			exclude = append(exclude, user.ID)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("reviewers.borrowed", len(picked)))
+	return picked, nil
+}
+
+// getCrossTeamReviewers returns reviewers that are not members of the
+// author's primary team.
+func (uc *UseCase) getCrossTeamReviewers(ctx context.Context, authorID string, reviewerIDs []string) ([]string, error) {
+	l := logger.FromCtx(ctx)
+	if len(reviewerIDs) == 0 {
+		return nil, nil
+	}
+
+	author, err := uc.userRepo.GetUserByID(ctx, authorID)
+	if err != nil {
+		l.Warn("failed to get author", zap.Error(err))
+		return nil, err
+	}
+
+	memberIDs, err := uc.userRepo.GetTeamMemberIDs(ctx, author.TeamName, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get team member IDs", zap.Error(err))
+		return nil, err
+	}
+
+	members := make(map[string]struct{}, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = struct{}{}
+	}
+
+	var crossTeam []string
+	for _, id := range reviewerIDs {
+		if _, ok := members[id]; !ok {
+			crossTeam = append(crossTeam, id)
+		}
+	}
+	return crossTeam, nil
+}
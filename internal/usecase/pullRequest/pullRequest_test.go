@@ -35,12 +35,12 @@ func newUseCaseWithMocks(t *testing.T) (*UseCase, *mocks.MockPullRequestReposito
 	teamRepo := mocks.NewMockTeamRepository(t)
 	trManager := &testManager{}
 
-	uc := NewUseCase(prRepo, userRepo, teamRepo, trManager)
+	uc := NewUseCase(prRepo, userRepo, teamRepo, trManager, 0)
 	return uc, prRepo, userRepo, teamRepo, trManager
 }
 
 func TestUseCase_CreatePullRequest_Success(t *testing.T) {
-	uc, prRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
+	uc, prRepo, userRepo, teamRepo, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	req := &entity.CreatePullRequestRequest{
@@ -69,8 +69,16 @@ func TestUseCase_CreatePullRequest_Success(t *testing.T) {
 		GetUserByID(ctx, req.AuthorID).
 		Return(author, nil)
 
+	teamRepo.EXPECT().
+		IsArchived(ctx, author.TeamName).
+		Return(false, nil)
+
+	teamRepo.EXPECT().
+		GetSettings(ctx, author.TeamName).
+		Return(entity.DefaultTeamSettings(author.TeamName), nil)
+
 	userRepo.EXPECT().
-		GetReviewersForPullRequest(ctx, author.TeamName, author.ID).
+		GetReviewersForPullRequest(ctx, author.TeamName, []string{author.ID}, 2, entity.StrategyRandom).
 		Return(reviewers, nil)
 
 	prRepo.EXPECT().
@@ -132,10 +140,43 @@ func TestUseCase_CreatePullRequest_AlreadyExists(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, entity.ErrAlreadyExists))
+	assert.True(t, errors.Is(err, entity.ErrPRExists))
 	assert.True(t, trManager.doCalled)
 	userRepo.AssertNotCalled(t, "GetUserByID", mock.Anything, mock.Anything)
 }
 
+func TestUseCase_CreatePullRequest_TeamArchived(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+	}
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "team-1"}
+
+	prRepo.EXPECT().
+		CheckPullRequestIDExists(ctx, req.PullRequestID).
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.AuthorID).
+		Return(author, nil)
+
+	teamRepo.EXPECT().
+		IsArchived(ctx, author.TeamName).
+		Return(true, nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrTeamArchived))
+	assert.True(t, trManager.doCalled)
+
+	prRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestUseCase_CreatePullRequest_CheckExistsError(t *testing.T) {
 	uc, prRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
 
@@ -243,7 +284,7 @@ func TestUseCase_MergePullRequest_CheckExistsError(t *testing.T) {
 }
 
 func TestUseCase_ReassignPullRequest_Success(t *testing.T) {
-	uc, prRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
+	uc, prRepo, userRepo, teamRepo, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	req := &entity.ReassignPullRequestRequest{
@@ -274,7 +315,8 @@ func TestUseCase_ReassignPullRequest_Success(t *testing.T) {
 
 	prRepo.EXPECT().
 		GetPullRequestByID(ctx, req.PullRequestID).
-		Return(pullRequest, nil)
+		Return(pullRequest, nil).
+		Once()
 
 	userRepo.EXPECT().
 		GetUserByID(ctx, req.OldUserID).
@@ -282,10 +324,15 @@ func TestUseCase_ReassignPullRequest_Success(t *testing.T) {
 
 	prRepo.EXPECT().
 		GetReviewersByPullRequestID(ctx, req.PullRequestID).
-		Return(reviewersBefore, nil)
+		Return(reviewersBefore, nil).
+		Once()
+
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
 
 	userRepo.EXPECT().
-		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything).
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
 		Return(newReviewer, nil)
 
 	prRepo.EXPECT().
@@ -311,6 +358,14 @@ func TestUseCase_ReassignPullRequest_Success(t *testing.T) {
 		GetReviewersByPullRequestID(ctx, req.PullRequestID).
 		Return(reviewersAfter, nil)
 
+	userRepo.EXPECT().
+		GetUserByID(ctx, "author-1").
+		Return(&entity.User{ID: "author-1", TeamName: "team-1"}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, "team-1", reviewersAfter).
+		Return(reviewersAfter, nil)
+
 	result, err := uc.ReassignPullRequest(ctx, req)
 
 	assert.NoError(t, err)
@@ -319,6 +374,7 @@ func TestUseCase_ReassignPullRequest_Success(t *testing.T) {
 	assert.NotNil(t, result.PullRequestResponse)
 	assert.Equal(t, updatedPR.ID, result.PR.ID)
 	assert.Equal(t, newReviewer.ID, result.ReplacedBy)
+	assert.Empty(t, result.PR.CrossTeamReviewers)
 }
 
 func TestUseCase_ReassignPullRequest_PRMerged(t *testing.T) {
@@ -391,11 +447,11 @@ func TestUseCase_ReassignPullRequest_NotAssignedReviewer(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, entity.ErrNotAssignedReviewer))
 	assert.True(t, trManager.doCalled)
-	userRepo.AssertNotCalled(t, "GetReplacementReviewerForPullRequest", mock.Anything, mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "GetReplacementReviewerForPullRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestUseCase_ReassignPullRequest_NoCandidate(t *testing.T) {
-	uc, prRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
+	uc, prRepo, userRepo, teamRepo, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	req := &entity.ReassignPullRequestRequest{
@@ -430,10 +486,22 @@ func TestUseCase_ReassignPullRequest_NoCandidate(t *testing.T) {
 		GetReviewersByPullRequestID(ctx, req.PullRequestID).
 		Return(reviewers, nil)
 
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
+
 	userRepo.EXPECT().
-		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything).
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
 		Return(nil, entity.ErrNotFound)
 
+	teamRepo.EXPECT().
+		IsLeadEscalationEnabled(ctx, oldUser.TeamName).
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		HasCappedCandidates(ctx, oldUser.TeamName, mock.Anything).
+		Return(false, nil)
+
 	result, err := uc.ReassignPullRequest(ctx, req)
 
 	assert.Nil(t, result)
@@ -441,3 +509,399 @@ func TestUseCase_ReassignPullRequest_NoCandidate(t *testing.T) {
 	assert.True(t, errors.Is(err, entity.ErrNoCandidate))
 	assert.True(t, trManager.doCalled)
 }
+
+func TestUseCase_ReassignPullRequest_EscalatesToLead(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ReassignPullRequestRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "old-1",
+	}
+
+	oldUser := &entity.User{ID: "old-1", Username: "old", IsActive: true, TeamName: "team-1"}
+
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: "author-1", Status: entity.StatusOpen}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.OldUserID).Return(oldUser, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"old-1"}, nil).
+		Once()
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
+
+	userRepo.EXPECT().
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
+		Return(nil, entity.ErrNotFound)
+
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, oldUser.TeamName).Return(true, nil)
+	userRepo.EXPECT().
+		GetLeadIDs(ctx, oldUser.TeamName, []string{"old-1", "author-1"}).
+		Return([]string{"lead-1"}, nil)
+
+	prRepo.EXPECT().RemoveReviewer(ctx, req.PullRequestID, oldUser.ID).Return(nil)
+	prRepo.EXPECT().AddNewReviewer(ctx, req.PullRequestID, "lead-1").Return(nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"lead-1"}, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "author-1").
+		Return(&entity.User{ID: "author-1", TeamName: "team-1"}, nil)
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, "team-1", []string{"lead-1"}).
+		Return([]string{"lead-1"}, nil)
+
+	result, err := uc.ReassignPullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "lead-1", result.ReplacedBy)
+	assert.True(t, result.Escalated)
+}
+
+func TestUseCase_ReassignPullRequest_NoEligibleLead(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ReassignPullRequestRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "old-1",
+	}
+
+	oldUser := &entity.User{ID: "old-1", Username: "old", IsActive: true, TeamName: "team-1"}
+
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: "author-1", Status: entity.StatusOpen}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.OldUserID).Return(oldUser, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"old-1"}, nil)
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
+
+	userRepo.EXPECT().
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
+		Return(nil, entity.ErrNotFound)
+
+	// The only lead is inactive, away or at their cap, so none is returned.
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, oldUser.TeamName).Return(true, nil)
+	userRepo.EXPECT().
+		GetLeadIDs(ctx, oldUser.TeamName, []string{"old-1", "author-1"}).
+		Return([]string{}, nil)
+	userRepo.EXPECT().
+		HasCappedCandidates(ctx, oldUser.TeamName, []string{"old-1", "author-1"}).
+		Return(false, nil)
+
+	result, err := uc.ReassignPullRequest(ctx, req)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrNoCandidate))
+
+	prRepo.AssertNotCalled(t, "AddNewReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ReassignPullRequest_QueuesWhenTeamAtCap(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ReassignPullRequestRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "old-1",
+	}
+
+	oldUser := &entity.User{ID: "old-1", Username: "old", IsActive: true, TeamName: "team-1"}
+
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: "author-1", Status: entity.StatusOpen}, nil).
+		Once()
+	userRepo.EXPECT().GetUserByID(ctx, req.OldUserID).Return(oldUser, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"old-1"}, nil).
+		Once()
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
+	userRepo.EXPECT().
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
+		Return(nil, entity.ErrNotFound)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, oldUser.TeamName).Return(false, nil)
+	userRepo.EXPECT().
+		HasCappedCandidates(ctx, oldUser.TeamName, []string{"old-1", "author-1"}).
+		Return(true, nil)
+
+	prRepo.EXPECT().RemoveReviewer(ctx, req.PullRequestID, oldUser.ID).Return(nil)
+	prRepo.EXPECT().EnqueueAwaitingReviewers(ctx, req.PullRequestID, 1).Return(nil)
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: "author-1", Status: entity.StatusOpen, AwaitingReviewers: 1}, nil)
+	prRepo.EXPECT().GetReviewersByPullRequestID(ctx, req.PullRequestID).Return([]string{}, nil)
+
+	result, err := uc.ReassignPullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.ReplacedBy)
+	assert.Equal(t, 1, result.PR.AwaitingReviewers)
+	prRepo.AssertNotCalled(t, "AddNewReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_CreatePullRequest_QueuesMissingReviewers(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+	}
+
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "backend"}
+	teammate := &entity.User{ID: "rev-1", Username: "rev1", IsActive: true, TeamName: "backend"}
+
+	prRepo.EXPECT().CheckPullRequestIDExists(ctx, req.PullRequestID).Return(false, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.AuthorID).Return(author, nil)
+	teamRepo.EXPECT().IsArchived(ctx, author.TeamName).Return(false, nil)
+	teamRepo.EXPECT().
+		GetSettings(ctx, author.TeamName).
+		Return(entity.DefaultTeamSettings(author.TeamName), nil)
+	userRepo.EXPECT().
+		GetReviewersForPullRequest(ctx, author.TeamName, []string{author.ID}, 2, entity.StrategyRandom).
+		Return([]*entity.User{teammate}, nil)
+	prRepo.EXPECT().Create(ctx, mock.Anything).Return(nil)
+	prRepo.EXPECT().AssignReviewers(ctx, req.PullRequestID, []string{"rev-1"}).Return(nil)
+	prRepo.EXPECT().EnqueueAwaitingReviewers(ctx, req.PullRequestID, 1).Return(nil)
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: req.AuthorID, Status: entity.StatusOpen, AwaitingReviewers: 1}, nil)
+	prRepo.EXPECT().GetReviewersByPullRequestID(ctx, req.PullRequestID).Return([]string{"rev-1"}, nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"rev-1"}, result.Reviewers)
+	assert.Equal(t, 1, result.AwaitingReviewers)
+}
+
+func TestUseCase_CreatePullRequest_FallbackToSiblingTeam(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+	uc.fallbackDepth = 2
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+	}
+
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "backend"}
+	teammate := &entity.User{ID: "rev-1", Username: "rev1", IsActive: true, TeamName: "backend"}
+	borrowed := &entity.User{ID: "rev-2", Username: "rev2", IsActive: true, TeamName: "frontend"}
+	reviewerIDs := []string{"rev-1", "rev-2"}
+
+	prRepo.EXPECT().CheckPullRequestIDExists(ctx, req.PullRequestID).Return(false, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.AuthorID).Return(author, nil)
+	teamRepo.EXPECT().IsArchived(ctx, author.TeamName).Return(false, nil)
+	teamRepo.EXPECT().
+		GetSettings(ctx, author.TeamName).
+		Return(entity.DefaultTeamSettings(author.TeamName), nil)
+
+	userRepo.EXPECT().
+		GetReviewersForPullRequest(ctx, author.TeamName, []string{author.ID}, 2, entity.StrategyRandom).
+		Return([]*entity.User{teammate}, nil)
+
+	teamRepo.EXPECT().GetParentName(ctx, "backend").Return("engineering", nil)
+	teamRepo.EXPECT().GetChildNames(ctx, "engineering").Return([]string{"backend", "frontend"}, nil)
+	userRepo.EXPECT().
+		GetActiveUsersFromTeams(ctx, []string{"frontend"}, []string{"author-1", "rev-1"}, 1).
+		Return([]*entity.User{borrowed}, nil)
+
+	prRepo.EXPECT().Create(ctx, mock.Anything).Return(nil)
+	prRepo.EXPECT().AssignReviewers(ctx, req.PullRequestID, reviewerIDs).Return(nil)
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: req.AuthorID, Status: entity.StatusOpen}, nil)
+	prRepo.EXPECT().GetReviewersByPullRequestID(ctx, req.PullRequestID).Return(reviewerIDs, nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, reviewerIDs, result.Reviewers)
+	assert.Equal(t, []string{"rev-2"}, result.CrossTeamReviewers)
+}
+
+func TestUseCase_ReassignPullRequest_FallbackToParentTeam(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+	uc.fallbackDepth = 2
+
+	ctx := context.Background()
+	req := &entity.ReassignPullRequestRequest{
+		PullRequestID: "pr-1",
+		OldUserID:     "old-1",
+	}
+
+	oldUser := &entity.User{ID: "old-1", Username: "old", IsActive: true, TeamName: "backend"}
+	lead := &entity.User{ID: "lead-1", Username: "lead", IsActive: true, TeamName: "engineering"}
+
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: "author-1", Status: entity.StatusOpen}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.OldUserID).Return(oldUser, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"old-1"}, nil).
+		Once()
+	teamRepo.EXPECT().
+		GetSettings(ctx, oldUser.TeamName).
+		Return(entity.DefaultTeamSettings(oldUser.TeamName), nil)
+
+	userRepo.EXPECT().
+		GetReplacementReviewerForPullRequest(ctx, oldUser.TeamName, mock.Anything, entity.StrategyRandom).
+		Return(nil, entity.ErrNotFound)
+
+	teamRepo.EXPECT().GetParentName(ctx, "backend").Return("engineering", nil)
+	teamRepo.EXPECT().GetChildNames(ctx, "engineering").Return([]string{"backend"}, nil)
+	userRepo.EXPECT().
+		GetActiveUsersFromTeams(ctx, []string{"engineering"}, mock.Anything, 1).
+		Return([]*entity.User{lead}, nil)
+
+	prRepo.EXPECT().RemoveReviewer(ctx, req.PullRequestID, oldUser.ID).Return(nil)
+	prRepo.EXPECT().AddNewReviewer(ctx, req.PullRequestID, lead.ID).Return(nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestID(ctx, req.PullRequestID).
+		Return([]string{"lead-1"}, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "author-1").
+		Return(&entity.User{ID: "author-1", TeamName: "backend"}, nil)
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, "backend", []string{"lead-1"}).
+		Return([]string{}, nil)
+
+	result, err := uc.ReassignPullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, lead.ID, result.ReplacedBy)
+	assert.Equal(t, []string{"lead-1"}, result.PR.CrossTeamReviewers)
+}
+
+func TestUseCase_CreatePullRequest_AuthorChoosesReviewers(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+		ReviewerIDs:   []string{"rev-1"},
+	}
+
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "backend"}
+	settings := entity.DefaultTeamSettings("backend")
+	settings.AuthorChoosesReviewers = true
+	settings.SelectionStrategy = entity.StrategyLeastLoaded
+	reviewerIDs := []string{"rev-1", "rev-2"}
+
+	prRepo.EXPECT().CheckPullRequestIDExists(ctx, req.PullRequestID).Return(false, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.AuthorID).Return(author, nil)
+	teamRepo.EXPECT().IsArchived(ctx, author.TeamName).Return(false, nil)
+	teamRepo.EXPECT().GetSettings(ctx, author.TeamName).Return(settings, nil)
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, []string{"rev-1"}).
+		Return([]*entity.User{{ID: "rev-1", IsActive: true, TeamName: "backend"}}, nil)
+	userRepo.EXPECT().GetTeamMemberIDs(ctx, "backend", []string{"rev-1"}).Return([]string{"rev-1"}, nil)
+	userRepo.EXPECT().
+		GetReviewersForPullRequest(ctx, "backend", []string{"author-1", "rev-1"}, 1, entity.StrategyLeastLoaded).
+		Return([]*entity.User{{ID: "rev-2", IsActive: true, TeamName: "backend"}}, nil)
+
+	prRepo.EXPECT().Create(ctx, mock.Anything).Return(nil)
+	prRepo.EXPECT().AssignReviewers(ctx, req.PullRequestID, reviewerIDs).Return(nil)
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: req.AuthorID, Status: entity.StatusOpen}, nil)
+	prRepo.EXPECT().GetReviewersByPullRequestID(ctx, req.PullRequestID).Return(reviewerIDs, nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, reviewerIDs, result.Reviewers)
+}
+
+func TestUseCase_CreatePullRequest_ReviewerChoiceOff(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+		ReviewerIDs:   []string{"rev-1"},
+	}
+
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "backend"}
+
+	prRepo.EXPECT().CheckPullRequestIDExists(ctx, req.PullRequestID).Return(false, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.AuthorID).Return(author, nil)
+	teamRepo.EXPECT().IsArchived(ctx, author.TeamName).Return(false, nil)
+	teamRepo.EXPECT().GetSettings(ctx, author.TeamName).Return(entity.DefaultTeamSettings("backend"), nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrReviewerChoiceOff))
+	prRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_CreatePullRequest_CrossTeamFallbackDisabled(t *testing.T) {
+	uc, prRepo, userRepo, teamRepo, _ := newUseCaseWithMocks(t)
+	uc.fallbackDepth = 2
+
+	ctx := context.Background()
+	req := &entity.CreatePullRequestRequest{
+		PullRequestID: "pr-1",
+		Name:          "Test PR",
+		AuthorID:      "author-1",
+	}
+
+	author := &entity.User{ID: "author-1", Username: "author", IsActive: true, TeamName: "backend"}
+	settings := entity.DefaultTeamSettings("backend")
+	settings.CrossTeamFallback = false
+
+	prRepo.EXPECT().CheckPullRequestIDExists(ctx, req.PullRequestID).Return(false, nil)
+	userRepo.EXPECT().GetUserByID(ctx, req.AuthorID).Return(author, nil)
+	teamRepo.EXPECT().IsArchived(ctx, author.TeamName).Return(false, nil)
+	teamRepo.EXPECT().GetSettings(ctx, author.TeamName).Return(settings, nil)
+	userRepo.EXPECT().
+		GetReviewersForPullRequest(ctx, "backend", []string{"author-1"}, 2, entity.StrategyRandom).
+		Return([]*entity.User{}, nil)
+	prRepo.EXPECT().EnqueueAwaitingReviewers(ctx, req.PullRequestID, 2).Return(nil)
+
+	prRepo.EXPECT().Create(ctx, mock.Anything).Return(nil)
+	prRepo.EXPECT().
+		GetPullRequestByID(ctx, req.PullRequestID).
+		Return(&entity.PullRequest{ID: req.PullRequestID, AuthorID: req.AuthorID, Status: entity.StatusOpen}, nil)
+	prRepo.EXPECT().GetReviewersByPullRequestID(ctx, req.PullRequestID).Return([]string{}, nil)
+
+	result, err := uc.CreatePullRequest(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Reviewers)
+	teamRepo.AssertNotCalled(t, "GetParentName", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_GetBacklog_TeamNotFound(t *testing.T) {
+	uc, prRepo, _, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "missing").Return(false, nil)
+
+	result, err := uc.GetBacklog(ctx, "missing")
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrTeamNotFound))
+	prRepo.AssertNotCalled(t, "GetBacklog", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,911 @@
+package reassignment
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// Planner moves open reviews away from users that leave the reviewer pool,
+// e.g. on mass deactivation or removal from a team.
+type Planner struct {
+	userRepo        usecase.UserRepository
+	pullRequestRepo usecase.PullRequestRepository
+	teamRepo        usecase.TeamRepository
+}
+
+func NewPlanner(userRepo usecase.UserRepository, pullRequestRepo usecase.PullRequestRepository, teamRepo usecase.TeamRepository) *Planner {
+	return &Planner{
+		userRepo:        userRepo,
+		pullRequestRepo: pullRequestRepo,
+		teamRepo:        teamRepo,
+	}
+}
+
+type Plan struct {
+	Reassignments []*entity.ReviewReassignment
+	Unreassigned  []*entity.UnreassignedReview
+	ToRemove      []*entity.ReviewRecord
+	ToAdd         []*entity.ReviewRecord
+	prior         *Plan // not yet applied plan this one is built on top of
+}
+
+// Append adds the moves of other to the plan, e.g. to apply plans built for
+// several teams at once.
+func (plan *Plan) Append(other *Plan) {
+	plan.Reassignments = append(plan.Reassignments, other.Reassignments...)
+	plan.Unreassigned = append(plan.Unreassigned, other.Unreassigned...)
+	plan.ToRemove = append(plan.ToRemove, other.ToRemove...)
+	plan.ToAdd = append(plan.ToAdd, other.ToAdd...)
+}
+
+// pendingAdds returns reviewers the prior plan adds, which are not visible in
+// the database yet.
+func (plan *Plan) pendingAdds() []*entity.ReviewRecord {
+	if plan.prior == nil {
+		return nil
+	}
+	return plan.prior.ToAdd
+}
+
+func newPlan() *Plan {
+	return &Plan{
+		Reassignments: make([]*entity.ReviewReassignment, 0),
+		Unreassigned:  make([]*entity.UnreassignedReview, 0),
+		ToRemove:      make([]*entity.ReviewRecord, 0),
+		ToAdd:         make([]*entity.ReviewRecord, 0),
+	}
+}
+
+// PlanOf rebuilds the plan that makes the given moves, e.g. one that was
+// previewed earlier.
+func PlanOf(reassignments []*entity.ReviewReassignment, unreassigned []*entity.UnreassignedReview) *Plan {
+	plan := newPlan()
+	plan.Unreassigned = append(plan.Unreassigned, unreassigned...)
+	for _, reassignment := range reassignments {
+		plan.Reassignments = append(plan.Reassignments, reassignment)
+		plan.ToRemove = append(plan.ToRemove, &entity.ReviewRecord{
+			PullRequestID: reassignment.PullRequestID,
+			ReviewerID:    reassignment.OldReviewerID,
+		})
+		plan.ToAdd = append(plan.ToAdd, &entity.ReviewRecord{
+			PullRequestID: reassignment.PullRequestID,
+			ReviewerID:    reassignment.NewReviewerID,
+		})
+	}
+	return plan
+}
+
+// CandidateIDs returns active members of the team that can take over reviews
+// of userIDs, and the set of users that must never be picked.
+func (p *Planner) CandidateIDs(ctx context.Context, teamName string, userIDs []string) ([]string, map[string]struct{}, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.CandidateIDs")
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	candidateIDs, err := p.userRepo.GetActiveUsersIDsByTeamName(ctx, teamName, userIDs)
+	if err != nil {
+		l.Warn("failed to get active users by team name", zap.Error(err))
+		return nil, nil, err
+	}
+
+	excludeSet := make(map[string]struct{}, len(userIDs))
+	for _, id := range userIDs {
+		excludeSet[id] = struct{}{}
+	}
+
+	if len(candidateIDs) == 0 {
+		return candidateIDs, excludeSet, nil
+	}
+
+	filteredCandidates := make([]string, 0, len(candidateIDs))
+	for _, id := range candidateIDs {
+		if _, excluded := excludeSet[id]; !excluded {
+			filteredCandidates = append(filteredCandidates, id)
+		}
+	}
+
+	return filteredCandidates, excludeSet, nil
+}
+
+// PlanHandOver plans handing open reviews of userIDs over to active members of
+// teamName the way mass deactivation does, escalating to the team leads what
+// nobody can take. With teamOnly set only reviews on PRs of the team move, for
+// users that leave the team but stay in others.
+func (p *Planner) PlanHandOver(ctx context.Context, teamName string, userIDs []string, teamOnly bool) (*Plan, error) {
+	candidateIDs, excludeSet, err := p.CandidateIDs(ctx, teamName, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *Plan
+	if teamOnly {
+		plan, err = p.BuildForTeam(ctx, teamName, candidateIDs, excludeSet, userIDs)
+	} else {
+		plan, err = p.Build(ctx, candidateIDs, excludeSet, userIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if teamName != "" {
+		if err = p.EscalateToLeads(ctx, teamName, userIDs, plan); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// Build spreads open reviews of reviewerIDs over candidateIDs round-robin,
+// starting from a random candidate and skipping the PR author, those already
+// on the PR and those at their cap of open reviews.
+func (p *Planner) Build(
+	ctx context.Context,
+	candidateIDs []string,
+	excludeSet map[string]struct{},
+	reviewerIDs []string,
+) (*Plan, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.Build", attribute.Int("candidates.count", len(candidateIDs)))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	reviewRecords, err := p.pullRequestRepo.GetReviewsByReviewerIDs(ctx, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get reviews by reviewer IDs", zap.Error(err))
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("reviews.count", len(reviewRecords)))
+	return p.build(ctx, nil, candidateIDs, excludeSet, reviewRecords)
+}
+
+// BuildAfter is like Build for a plan that is applied together with prior:
+// reviewers prior adds count as already assigned and against their cap.
+func (p *Planner) BuildAfter(
+	ctx context.Context,
+	prior *Plan,
+	candidateIDs []string,
+	excludeSet map[string]struct{},
+	reviewerIDs []string,
+) (*Plan, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.BuildAfter", attribute.Int("candidates.count", len(candidateIDs)))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	reviewRecords, err := p.pullRequestRepo.GetReviewsByReviewerIDs(ctx, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get reviews by reviewer IDs", zap.Error(err))
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("reviews.count", len(reviewRecords)))
+	return p.build(ctx, prior, candidateIDs, excludeSet, reviewRecords)
+}
+
+// BuildForTeam is like Build but only moves reviews on PRs authored by members
+// of teamName, for users that leave the team but stay in others.
+func (p *Planner) BuildForTeam(
+	ctx context.Context,
+	teamName string,
+	candidateIDs []string,
+	excludeSet map[string]struct{},
+	reviewerIDs []string,
+) (*Plan, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.BuildForTeam",
+		attribute.String("team.name", teamName),
+		attribute.Int("candidates.count", len(candidateIDs)),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	reviewRecords, err := p.pullRequestRepo.GetTeamReviewsByReviewerIDs(ctx, teamName, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get team reviews by reviewer IDs", zap.Error(err))
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("reviews.count", len(reviewRecords)))
+	return p.build(ctx, nil, candidateIDs, excludeSet, reviewRecords)
+}
+
+func (p *Planner) build(
+	ctx context.Context,
+	prior *Plan,
+	candidateIDs []string,
+	excludeSet map[string]struct{},
+	reviewRecords []*entity.ReviewRecord,
+) (*Plan, error) {
+	l := logger.FromCtx(ctx)
+	plan := newPlan()
+	plan.prior = prior
+
+	if len(reviewRecords) == 0 {
+		return plan, nil
+	}
+
+	if len(candidateIDs) == 0 {
+		for _, record := range reviewRecords {
+			if record == nil {
+				continue
+			}
+			plan.Unreassigned = append(plan.Unreassigned, &entity.UnreassignedReview{
+				PullRequestID: record.PullRequestID,
+				ReviewerID:    record.ReviewerID,
+			})
+		}
+		return plan, nil
+	}
+
+	prIDSet := make(map[string]struct{})
+	for _, record := range reviewRecords {
+		if record != nil {
+			prIDSet[record.PullRequestID] = struct{}{}
+		}
+	}
+
+	prIDs := make([]string, 0, len(prIDSet))
+	for id := range prIDSet {
+		prIDs = append(prIDs, id)
+	}
+
+	reviewersMap, err := p.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
+		return nil, err
+	}
+
+	authors, err := p.pullRequestRepo.GetAuthorsByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		l.Warn("failed to get authors by pull request IDs", zap.Error(err))
+		return nil, err
+	}
+
+	reviewersByPR := make(map[string]map[string]struct{}, len(reviewersMap))
+	for prID, reviewers := range reviewersMap {
+		set := make(map[string]struct{}, len(reviewers))
+		for _, reviewerID := range reviewers {
+			if reviewerID != "" {
+				set[reviewerID] = struct{}{}
+			}
+		}
+		reviewersByPR[prID] = set
+	}
+
+	capacities, err := p.userRepo.GetReviewCapacities(ctx, candidateIDs)
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return nil, err
+	}
+
+	for _, record := range plan.pendingAdds() {
+		if set, ok := reviewersByPR[record.PullRequestID]; ok {
+			set[record.ReviewerID] = struct{}{}
+		}
+		if _, capped := capacities[record.ReviewerID]; capped {
+			capacities[record.ReviewerID]--
+		}
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	currentIndex := r.Intn(len(candidateIDs))
+
+	for _, record := range reviewRecords {
+		if record == nil {
+			continue
+		}
+
+		prID := record.PullRequestID
+		oldReviewerID := record.ReviewerID
+
+		existingReviewers, ok := reviewersByPR[prID]
+		if !ok {
+			existingReviewers = make(map[string]struct{})
+			reviewersByPR[prID] = existingReviewers
+		}
+
+		var replacementID string
+		for i := 0; i < len(candidateIDs); i++ {
+			idx := (currentIndex + i) % len(candidateIDs)
+			candidateID := candidateIDs[idx]
+			if candidateID == "" || candidateID == authors[prID] {
+				continue
+			}
+			if _, excluded := excludeSet[candidateID]; excluded {
+				continue
+			}
+			if _, alreadyAssigned := existingReviewers[candidateID]; alreadyAssigned {
+				continue
+			}
+			if left, capped := capacities[candidateID]; capped && left <= 0 {
+				continue
+			}
+			replacementID = candidateID
+			currentIndex = (idx + 1) % len(candidateIDs)
+			break
+		}
+
+		if replacementID == "" {
+			plan.Unreassigned = append(plan.Unreassigned, &entity.UnreassignedReview{
+				PullRequestID: prID,
+				ReviewerID:    oldReviewerID,
+			})
+			continue
+		}
+
+		plan.Reassignments = append(plan.Reassignments, &entity.ReviewReassignment{
+			PullRequestID: prID,
+			OldReviewerID: oldReviewerID,
+			NewReviewerID: replacementID,
+		})
+
+		plan.ToRemove = append(plan.ToRemove, &entity.ReviewRecord{
+			PullRequestID: prID,
+			ReviewerID:    oldReviewerID,
+		})
+		plan.ToAdd = append(plan.ToAdd, &entity.ReviewRecord{
+			PullRequestID: prID,
+			ReviewerID:    replacementID,
+		})
+
+		existingReviewers[replacementID] = struct{}{}
+		if _, capped := capacities[replacementID]; capped {
+			capacities[replacementID]--
+		}
+	}
+
+	return plan, nil
+}
+
+// EscalateToLeads hands reviews the plan could not reassign over to the leads
+// of teamName other than userIDs, if the team has lead escalation enabled.
+func (p *Planner) EscalateToLeads(ctx context.Context, teamName string, userIDs []string, plan *Plan) error {
+	l := logger.FromCtx(ctx)
+	if len(plan.Unreassigned) == 0 {
+		return nil
+	}
+
+	enabled, err := p.teamRepo.IsLeadEscalationEnabled(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check lead escalation", zap.Error(err))
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	leadIDs, err := p.userRepo.GetLeadIDs(ctx, teamName, userIDs)
+	if err != nil {
+		l.Warn("failed to get team leads", zap.Error(err))
+		return err
+	}
+
+	return p.Escalate(ctx, plan, leadIDs)
+}
+
+// Escalate hands reviews the plan left unreassigned over to leadIDs in turn,
+// skipping the PR author, leads already on the PR and leads who reached their
+// review cap. Reviews no lead can take stay unreassigned.
+func (p *Planner) Escalate(ctx context.Context, plan *Plan, leadIDs []string) error {
+	ctx, span := tracing.Start(ctx, "reassignment.Escalate",
+		attribute.Int("leads.count", len(leadIDs)),
+		attribute.Int("reviews.count", len(plan.Unreassigned)),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	if len(plan.Unreassigned) == 0 || len(leadIDs) == 0 {
+		return nil
+	}
+
+	prIDs := make([]string, 0, len(plan.Unreassigned))
+	for _, review := range plan.Unreassigned {
+		prIDs = append(prIDs, review.PullRequestID)
+	}
+
+	reviewersMap, err := p.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
+		return err
+	}
+
+	authors, err := p.pullRequestRepo.GetAuthorsByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		l.Warn("failed to get authors by pull request IDs", zap.Error(err))
+		return err
+	}
+
+	capacities, err := p.userRepo.GetReviewCapacities(ctx, leadIDs)
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return err
+	}
+
+	reviewersByPR := make(map[string]map[string]struct{}, len(reviewersMap))
+	for prID, reviewers := range reviewersMap {
+		set := make(map[string]struct{}, len(reviewers))
+		for _, reviewerID := range reviewers {
+			set[reviewerID] = struct{}{}
+		}
+		reviewersByPR[prID] = set
+	}
+	added := make([]*entity.ReviewRecord, 0, len(plan.pendingAdds())+len(plan.ToAdd))
+	added = append(append(added, plan.pendingAdds()...), plan.ToAdd...)
+	for _, record := range added {
+		if set, ok := reviewersByPR[record.PullRequestID]; ok {
+			set[record.ReviewerID] = struct{}{}
+		}
+		if _, capped := capacities[record.ReviewerID]; capped {
+			capacities[record.ReviewerID]--
+		}
+	}
+
+	remaining := make([]*entity.UnreassignedReview, 0)
+	next := 0
+	for _, review := range plan.Unreassigned {
+		existingReviewers, ok := reviewersByPR[review.PullRequestID]
+		if !ok {
+			existingReviewers = make(map[string]struct{})
+			reviewersByPR[review.PullRequestID] = existingReviewers
+		}
+
+		var leadID string
+		for i := 0; i < len(leadIDs); i++ {
+			idx := (next + i) % len(leadIDs)
+			if leadIDs[idx] == authors[review.PullRequestID] {
+				continue
+			}
+			if left, capped := capacities[leadIDs[idx]]; capped && left <= 0 {
+				continue
+			}
+			if _, assigned := existingReviewers[leadIDs[idx]]; !assigned {
+				leadID = leadIDs[idx]
+				next = (idx + 1) % len(leadIDs)
+				break
+			}
+		}
+
+		if leadID == "" {
+			remaining = append(remaining, review)
+			continue
+		}
+
+		plan.Reassignments = append(plan.Reassignments, &entity.ReviewReassignment{
+			PullRequestID: review.PullRequestID,
+			OldReviewerID: review.ReviewerID,
+			NewReviewerID: leadID,
+			Escalated:     true,
+		})
+		plan.ToRemove = append(plan.ToRemove, &entity.ReviewRecord{
+			PullRequestID: review.PullRequestID,
+			ReviewerID:    review.ReviewerID,
+		})
+		plan.ToAdd = append(plan.ToAdd, &entity.ReviewRecord{
+			PullRequestID: review.PullRequestID,
+			ReviewerID:    leadID,
+		})
+		existingReviewers[leadID] = struct{}{}
+		if _, capped := capacities[leadID]; capped {
+			capacities[leadID]--
+		}
+	}
+
+	span.SetAttributes(attribute.Int("reviews.escalated", len(plan.Unreassigned)-len(remaining)))
+	plan.Unreassigned = remaining
+	return nil
+}
+
+// Queue puts PRs with reviews the plan left unreassigned into the backlog, so
+// they are filled once someone can take them. The reviewer who takes such a
+// slot replaces the unreassigned one.
+func (p *Planner) Queue(ctx context.Context, plan *Plan) error {
+	ctx, span := tracing.Start(ctx, "reassignment.Queue", attribute.Int("reviews.count", len(plan.Unreassigned)))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	reviewers := make(map[string][]string)
+	prIDs := make([]string, 0)
+	for _, review := range plan.Unreassigned {
+		if _, seen := reviewers[review.PullRequestID]; !seen {
+			prIDs = append(prIDs, review.PullRequestID)
+		}
+		reviewers[review.PullRequestID] = append(reviewers[review.PullRequestID], review.ReviewerID)
+	}
+
+	for _, prID := range prIDs {
+		if err := p.pullRequestRepo.EnqueueReviewerReplacements(ctx, prID, reviewers[prID]); err != nil {
+			l.Warn("failed to queue pull request", zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// Backfill assigns userIDs to backlog PRs of their teams, oldest PRs first,
+// giving each PR to the user with the fewest reviews taken so far. A slot that
+// was queued for an unreassigned review replaces its reviewer if they are still
+// inactive or away, and counts as filled if they are back; other slots only add
+// a reviewer. Users stop at their cap of open reviews.
+func (p *Planner) Backfill(ctx context.Context, userIDs []string) ([]*entity.ReviewRecord, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.Backfill", attribute.Int("users.count", len(userIDs)))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	assigned := make([]*entity.ReviewRecord, 0)
+	if len(userIDs) == 0 {
+		return assigned, nil
+	}
+
+	backlog, err := p.pullRequestRepo.GetBacklogCandidates(ctx, userIDs)
+	if err != nil {
+		l.Warn("failed to get backlog candidates", zap.Error(err))
+		return nil, err
+	}
+	if len(backlog) == 0 {
+		return assigned, nil
+	}
+
+	capacities, err := p.userRepo.GetReviewCapacities(ctx, userIDs)
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return nil, err
+	}
+
+	taken := make(map[string]int, len(userIDs))
+	for _, entry := range backlog {
+		picked := make(map[string]struct{})
+		departed := make(map[string]struct{}, len(entry.DepartedReviewerIDs))
+		for _, id := range entry.DepartedReviewerIDs {
+			departed[id] = struct{}{}
+		}
+
+		for range entry.MissingReviewers {
+			var reviewerID string
+			for _, candidateID := range entry.CandidateIDs {
+				if _, ok := picked[candidateID]; ok {
+					continue
+				}
+				if left, capped := capacities[candidateID]; capped && left <= 0 {
+					continue
+				}
+				if reviewerID == "" || taken[candidateID] < taken[reviewerID] {
+					reviewerID = candidateID
+				}
+			}
+			if reviewerID == "" {
+				break
+			}
+
+			replacedID, dequeued, err := p.pullRequestRepo.DequeueAwaitingReviewer(ctx, entry.PullRequestID)
+			if err != nil {
+				l.Warn("failed to dequeue pull request", zap.Error(err))
+				return nil, err
+			}
+			if !dequeued {
+				break
+			}
+
+			if replacedID != "" {
+				if _, ok := departed[replacedID]; !ok {
+					// The reviewer is back and still holds the review.
+					continue
+				}
+				if err = p.pullRequestRepo.RemoveReviewer(ctx, entry.PullRequestID, replacedID); err != nil {
+					l.Warn("failed to remove departed reviewer", zap.Error(err))
+					return nil, err
+				}
+				delete(departed, replacedID)
+			}
+			if err = p.pullRequestRepo.AddNewReviewer(ctx, entry.PullRequestID, reviewerID); err != nil {
+				l.Warn("failed to add backlog reviewer", zap.Error(err))
+				return nil, err
+			}
+
+			picked[reviewerID] = struct{}{}
+			taken[reviewerID]++
+			if _, capped := capacities[reviewerID]; capped {
+				capacities[reviewerID]--
+			}
+			assigned = append(assigned, &entity.ReviewRecord{
+				PullRequestID: entry.PullRequestID,
+				ReviewerID:    reviewerID,
+			})
+		}
+	}
+
+	span.SetAttributes(attribute.Int("reviews.assigned", len(assigned)))
+	return assigned, nil
+}
+
+// BuildTakeOver plans moving open reviews of teamName's PRs from the most
+// loaded teammates to userID until the user has target open reviews or their
+// cap is reached. A review is only moved while its reviewer has at least two
+// more open reviews than the user, so the move never makes the load less even.
+// Like in BuildRebalance, loads and target count only reviews on the team's
+// PRs, while the user's cap counts open reviews across all teams.
+func (p *Planner) BuildTakeOver(ctx context.Context, userID, teamName string, target int) (*Plan, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.BuildTakeOver",
+		attribute.String("user.id", userID),
+		attribute.String("team.name", teamName),
+		attribute.Int("target", target),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	plan := newPlan()
+
+	records, err := p.pullRequestRepo.GetTransferableReviews(ctx, userID, teamName)
+	if err != nil {
+		l.Warn("failed to get transferable reviews", zap.Error(err))
+		return nil, err
+	}
+	if len(records) == 0 {
+		return plan, nil
+	}
+
+	byReviewer := make(map[string][]*entity.ReviewRecord)
+	ids := []string{userID}
+	for _, record := range records {
+		if _, seen := byReviewer[record.ReviewerID]; !seen {
+			ids = append(ids, record.ReviewerID)
+		}
+		byReviewer[record.ReviewerID] = append(byReviewer[record.ReviewerID], record)
+	}
+
+	pullRequests, err := p.pullRequestRepo.GetOpenPullRequestsByTeamName(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get open pull requests of team", zap.Error(err))
+		return nil, err
+	}
+
+	prIDs := make([]string, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		prIDs = append(prIDs, pr.ID)
+	}
+
+	reviewersMap, err := p.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+	if err != nil {
+		l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
+		return nil, err
+	}
+
+	loads := make(map[string]int, len(ids))
+	for _, reviewerIDs := range reviewersMap {
+		for _, reviewerID := range reviewerIDs {
+			loads[reviewerID]++
+		}
+	}
+
+	capacities, err := p.userRepo.GetReviewCapacities(ctx, []string{userID})
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return nil, err
+	}
+	if left, capped := capacities[userID]; capped && loads[userID]+left < target {
+		target = loads[userID] + left
+	}
+
+	taken := make(map[string]struct{})
+	for loads[userID] < target {
+		var donorID string
+		for _, id := range ids[1:] {
+			if len(byReviewer[id]) == 0 {
+				continue
+			}
+			if donorID == "" || loads[id] > loads[donorID] {
+				donorID = id
+			}
+		}
+		if donorID == "" || loads[donorID]-loads[userID] < 2 {
+			break
+		}
+
+		record := byReviewer[donorID][0]
+		byReviewer[donorID] = byReviewer[donorID][1:]
+		if _, ok := taken[record.PullRequestID]; ok {
+			continue
+		}
+		taken[record.PullRequestID] = struct{}{}
+
+		plan.Reassignments = append(plan.Reassignments, &entity.ReviewReassignment{
+			PullRequestID: record.PullRequestID,
+			OldReviewerID: donorID,
+			NewReviewerID: userID,
+		})
+		plan.ToRemove = append(plan.ToRemove, record)
+		plan.ToAdd = append(plan.ToAdd, &entity.ReviewRecord{
+			PullRequestID: record.PullRequestID,
+			ReviewerID:    userID,
+		})
+		loads[donorID]--
+		loads[userID]++
+	}
+
+	span.SetAttributes(attribute.Int("reviews.moved", len(plan.Reassignments)))
+	return plan, nil
+}
+
+// Balance is the open review load of team members a rebalancing works with.
+type Balance struct {
+	Mean        float64
+	OpenReviews map[string]int
+}
+
+// BuildRebalance plans moving open reviews of teamName's PRs between active
+// members until every member is within tolerance of the mean open review
+// count. Each move goes from a member to one with at least two fewer open
+// reviews, picking the pair with the largest gap, and never makes a member
+// review their own PR or sit on a PR twice. Newest PRs are moved first.
+// Loads count only reviews on the team's PRs, while members at their cap of
+// open reviews across all teams receive nothing. The returned balance holds
+// the loads after the plan is applied.
+func (p *Planner) BuildRebalance(ctx context.Context, teamName string, tolerance float64) (*Plan, *Balance, error) {
+	ctx, span := tracing.Start(ctx, "reassignment.BuildRebalance",
+		attribute.String("team.name", teamName),
+		attribute.Float64("tolerance", tolerance),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	plan := newPlan()
+
+	memberIDs, err := p.userRepo.GetAvailableMemberIDs(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get available team members", zap.Error(err))
+		return nil, nil, err
+	}
+
+	balance := &Balance{OpenReviews: make(map[string]int, len(memberIDs))}
+	if len(memberIDs) == 0 {
+		return plan, balance, nil
+	}
+
+	pullRequests, err := p.pullRequestRepo.GetOpenPullRequestsByTeamName(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get open pull requests of team", zap.Error(err))
+		return nil, nil, err
+	}
+
+	prIDs := make([]string, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		prIDs = append(prIDs, pr.ID)
+	}
+
+	var reviewersMap map[string][]string
+	if len(prIDs) > 0 {
+		reviewersMap, err = p.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+		if err != nil {
+			l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
+			return nil, nil, err
+		}
+	}
+
+	isMember := make(map[string]struct{}, len(memberIDs))
+	for _, id := range memberIDs {
+		isMember[id] = struct{}{}
+	}
+
+	loads := make(map[string]int, len(memberIDs))
+	for _, reviewerIDs := range reviewersMap {
+		for _, reviewerID := range reviewerIDs {
+			if _, ok := isMember[reviewerID]; ok {
+				loads[reviewerID]++
+			}
+		}
+	}
+
+	total := 0
+	for _, id := range memberIDs {
+		balance.OpenReviews[id] = loads[id]
+		total += loads[id]
+	}
+	balance.Mean = float64(total) / float64(len(memberIDs))
+	if len(memberIDs) < 2 || len(pullRequests) == 0 {
+		return plan, balance, nil
+	}
+
+	capacities, err := p.userRepo.GetReviewCapacities(ctx, memberIDs)
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return nil, nil, err
+	}
+
+	reviewersByPR := make(map[string]map[string]struct{}, len(pullRequests))
+	byReviewer := make(map[string][]*entity.PullRequest)
+	for i := len(pullRequests) - 1; i >= 0; i-- {
+		pr := pullRequests[i]
+		set := make(map[string]struct{}, len(reviewersMap[pr.ID]))
+		for _, reviewerID := range reviewersMap[pr.ID] {
+			set[reviewerID] = struct{}{}
+			if _, ok := isMember[reviewerID]; ok {
+				byReviewer[reviewerID] = append(byReviewer[reviewerID], pr)
+			}
+		}
+		reviewersByPR[pr.ID] = set
+	}
+
+	for {
+		var donorID, receiverID string
+		prIndex := -1
+		for _, d := range memberIDs {
+			for _, r := range memberIDs {
+				gap := loads[d] - loads[r]
+				if d == r || gap < 2 || (prIndex >= 0 && gap <= loads[donorID]-loads[receiverID]) {
+					continue
+				}
+				if float64(loads[d]) <= balance.Mean+tolerance && float64(loads[r]) >= balance.Mean-tolerance {
+					continue
+				}
+				if left, capped := capacities[r]; capped && left <= 0 {
+					continue
+				}
+				for i, pr := range byReviewer[d] {
+					if _, assigned := reviewersByPR[pr.ID][r]; pr.AuthorID == r || assigned {
+						continue
+					}
+					donorID, receiverID, prIndex = d, r, i
+					break
+				}
+			}
+		}
+		if prIndex < 0 {
+			break
+		}
+
+		pr := byReviewer[donorID][prIndex]
+		byReviewer[donorID] = append(byReviewer[donorID][:prIndex:prIndex], byReviewer[donorID][prIndex+1:]...)
+
+		plan.Reassignments = append(plan.Reassignments, &entity.ReviewReassignment{
+			PullRequestID: pr.ID,
+			OldReviewerID: donorID,
+			NewReviewerID: receiverID,
+		})
+		plan.ToRemove = append(plan.ToRemove, &entity.ReviewRecord{
+			PullRequestID: pr.ID,
+			ReviewerID:    donorID,
+		})
+		plan.ToAdd = append(plan.ToAdd, &entity.ReviewRecord{
+			PullRequestID: pr.ID,
+			ReviewerID:    receiverID,
+		})
+
+		reviewersByPR[pr.ID][receiverID] = struct{}{}
+		if _, capped := capacities[receiverID]; capped {
+			capacities[receiverID]--
+		}
+		loads[donorID]--
+		loads[receiverID]++
+		balance.OpenReviews[donorID] = loads[donorID]
+		balance.OpenReviews[receiverID] = loads[receiverID]
+	}
+
+	span.SetAttributes(attribute.Int("reviews.moved", len(plan.Reassignments)))
+	return plan, balance, nil
+}
+
+// Apply writes the reviewer changes of the plan. It must run in the same
+// transaction that built the plan.
+func (p *Planner) Apply(ctx context.Context, plan *Plan) error {
+	ctx, span := tracing.Start(ctx, "reassignment.Apply")
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	if len(plan.ToRemove) > 0 {
+		if err := p.pullRequestRepo.RemoveReviewersBatch(ctx, plan.ToRemove); err != nil {
+			l.Warn("failed to remove old reviewers in batch", zap.Error(err))
+			return err
+		}
+	}
+
+	if len(plan.ToAdd) > 0 {
+		if err := p.pullRequestRepo.AddReviewersBatch(ctx, plan.ToAdd); err != nil {
+			l.Warn("failed to add new reviewers in batch", zap.Error(err))
+			return err
+		}
+	}
+
+	return nil
+}
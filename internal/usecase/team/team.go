@@ -2,25 +2,38 @@ package team
 
 import (
 	"context"
+	"errors"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase/reassignment"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 type UseCase struct {
-	teamRepo   usecase.TeamRepository
-	userRepo   usecase.UserRepository
-	transactor trm.Manager
+	teamRepo        usecase.TeamRepository
+	userRepo        usecase.UserRepository
+	pullRequestRepo usecase.PullRequestRepository
+	transactor      trm.Manager
+	planner         *reassignment.Planner
 }
 
-func NewUseCase(teamRepo usecase.TeamRepository, userRepo usecase.UserRepository, transactor trm.Manager) *UseCase {
+func NewUseCase(
+	teamRepo usecase.TeamRepository,
+	userRepo usecase.UserRepository,
+	pullRequestRepo usecase.PullRequestRepository,
+	transactor trm.Manager,
+) *UseCase {
 	return &UseCase{
-		teamRepo:   teamRepo,
-		userRepo:   userRepo,
-		transactor: transactor,
+		teamRepo:        teamRepo,
+		userRepo:        userRepo,
+		pullRequestRepo: pullRequestRepo,
+		transactor:      transactor,
+		planner:         reassignment.NewPlanner(userRepo, pullRequestRepo, teamRepo),
 	}
 }
 
@@ -37,7 +50,23 @@ func checkMemberIDsUnique(members []*entity.Member) bool {
 	return true
 }
 
+func checkUserIDsUnique(ids []string) bool {
+	idsMap := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, exists := idsMap[id]; exists {
+			return false
+		}
+		idsMap[id] = struct{}{}
+	}
+	return true
+}
+
 func (uc *UseCase) CreateTeam(ctx context.Context, team *entity.Team) error {
+	ctx, span := tracing.Start(ctx, "team.CreateTeam",
+		attribute.String("team.name", team.Name),
+		attribute.Int("team.members", len(team.Members)),
+	)
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	if !checkMemberIDsUnique(team.Members) {
 		return entity.ErrDuplicateUserIDs
@@ -50,79 +79,586 @@ func (uc *UseCase) CreateTeam(ctx context.Context, team *entity.Team) error {
 			return err
 		}
 		if exists {
-			return entity.ErrAlreadyExists
+			return entity.ErrTeamExists
+		}
+
+		if team.ParentName != "" {
+			if err = uc.checkTeamExists(ctx, team.ParentName); err != nil {
+				return err
+			}
 		}
 
 		if err = uc.teamRepo.Create(ctx, team); err != nil {
+			if errors.Is(err, entity.ErrAlreadyExists) {
+				return entity.ErrTeamExists
+			}
 			return err
 		}
 
-		if len(team.Members) == 0 {
-			return nil
+		return uc.saveMembers(ctx, team.Members, team.Name, false)
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	return nil
+}
+
+func (uc *UseCase) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+	ctx, span := tracing.Start(ctx, "team.GetByName", attribute.String("team.name", name))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	team, err := uc.teamRepo.GetByName(ctx, name)
+	if err != nil {
+		l.Warn("failed to get team", zap.Error(err))
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	members, err := uc.userRepo.GetByTeamName(ctx, team.Name)
+	if err != nil {
+		l.Warn("failed to get team members", zap.Error(err))
+		return nil, err
+	}
+
+	team.Members = members
+	return team, nil
+}
+
+// saveMembers attaches members to the team, creating users that do not exist
+// yet. Existing users are moved from their current primary team, or only get
+// a secondary membership when secondary is set.
+func (uc *UseCase) saveMembers(ctx context.Context, members []*entity.Member, teamName string, secondary bool) error {
+	l := logger.FromCtx(ctx)
+	if len(members) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(members))
+	for _, member := range members {
+		if member != nil {
+			ids = append(ids, member.ID)
 		}
+	}
+
+	existingIDs, err := uc.userRepo.FindExistingByIDs(ctx, ids)
+	if err != nil {
+		l.Warn("failed to find existing user IDs", zap.Error(err))
+		return err
+	}
 
-		ids := make([]string, 0, len(team.Members))
-		for _, member := range team.Members {
-			if member != nil {
-				ids = append(ids, member.ID)
+	existingMembers := make([]*entity.Member, 0)
+	newMembers := make([]*entity.Member, 0)
+	for _, member := range members {
+		if member != nil {
+			if _, exists := existingIDs[member.ID]; exists {
+				existingMembers = append(existingMembers, member)
+			} else {
+				newMembers = append(newMembers, member)
 			}
 		}
+	}
+
+	switch {
+	case len(existingMembers) == 0:
+	case secondary:
+		if err = uc.userRepo.AddSecondaryMemberships(ctx, teamName, existingMembers); err != nil {
+			l.Warn("failed to add secondary memberships", zap.Error(err))
+			return err
+		}
+	default:
+		if err = uc.userRepo.UpdateMembers(ctx, existingMembers, teamName); err != nil {
+			l.Warn("failed to update existing users", zap.Error(err))
+			return err
+		}
+	}
+
+	if len(newMembers) > 0 {
+		if err = uc.userRepo.CreateBatch(ctx, newMembers, teamName); err != nil {
+			l.Warn("failed to create new users", zap.Error(err))
+			return err
+		}
+	}
+
+	// New members pick up PRs of the team that are still waiting for reviewers.
+	_, err = uc.planner.Backfill(ctx, ids)
+	return err
+}
+
+// checkTeamOpen fails unless teamName exists and is not archived.
+func (uc *UseCase) checkTeamOpen(ctx context.Context, teamName string) error {
+	l := logger.FromCtx(ctx)
+	if err := uc.checkTeamExists(ctx, teamName); err != nil {
+		return err
+	}
+
+	archived, err := uc.teamRepo.IsArchived(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check if team is archived", zap.Error(err))
+		return err
+	}
+	if archived {
+		return entity.ErrTeamArchived
+	}
+	return nil
+}
+
+func (uc *UseCase) checkTeamExists(ctx context.Context, teamName string) error {
+	l := logger.FromCtx(ctx)
+	exists, err := uc.teamRepo.CheckTeamNameExists(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check team existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return entity.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (uc *UseCase) AddMembers(ctx context.Context, req *entity.AddTeamMembersRequest) (*entity.Team, error) {
+	ctx, span := tracing.Start(ctx, "team.AddMembers",
+		attribute.String("team.name", req.TeamName),
+		attribute.Int("team.members", len(req.Members)),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	if !checkMemberIDsUnique(req.Members) {
+		return nil, entity.ErrDuplicateUserIDs
+	}
+
+	var team *entity.Team
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.checkTeamExists(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		if err := uc.saveMembers(ctx, req.Members, req.TeamName, req.Secondary); err != nil {
+			return err
+		}
+
+		members, err := uc.userRepo.GetByTeamName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get team members", zap.Error(err))
+			return err
+		}
+
+		team = &entity.Team{Name: req.TeamName, Members: members}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return team, nil
+}
+
+// RemoveMembers detaches users from the team and hands their open reviews on
+// the team's PRs over the way mass deactivation does: to the remaining active
+// members, then to the team leads, queueing what nobody can take.
+func (uc *UseCase) RemoveMembers(ctx context.Context, req *entity.RemoveTeamMembersRequest) (*entity.RemoveTeamMembersResponse, error) {
+	ctx, span := tracing.Start(ctx, "team.RemoveMembers",
+		attribute.String("team.name", req.TeamName),
+		attribute.Int("users.count", len(req.UserIDs)),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	if !checkUserIDsUnique(req.UserIDs) {
+		return nil, entity.ErrDuplicateUserIDs
+	}
+
+	var plan *reassignment.Plan
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.checkTeamOpen(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		users, err := uc.userRepo.GetUsersByIDs(ctx, req.UserIDs)
+		if err != nil {
+			l.Warn("failed to get users by IDs", zap.Error(err))
+			return err
+		}
+		if len(users) != len(req.UserIDs) {
+			return entity.ErrUserNotFound
+		}
 
-		existingIDs, err := uc.userRepo.FindExistingByIDs(ctx, ids)
+		memberIDs, err := uc.userRepo.GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs)
 		if err != nil {
-			l.Warn("failed to find existing user IDs", zap.Error(err))
+			l.Warn("failed to get team member IDs", zap.Error(err))
+			return err
+		}
+		if len(memberIDs) != len(req.UserIDs) {
+			l.Warn("users are not members of the team", zap.String("teamName", req.TeamName))
+			return entity.ErrNotTeamMember
+		}
+
+		plan, err = uc.planner.PlanHandOver(ctx, req.TeamName, req.UserIDs, true)
+		if err != nil {
+			return err
+		}
+
+		if err = uc.userRepo.RemoveFromTeam(ctx, req.TeamName, req.UserIDs); err != nil {
+			l.Warn("failed to remove users from team", zap.Error(err))
+			return err
+		}
+
+		if err = uc.planner.Apply(ctx, plan); err != nil {
 			return err
 		}
+		return uc.planner.Queue(ctx, plan)
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("reviews.reassigned", len(plan.Reassignments)),
+		attribute.Int("reviews.unreassigned", len(plan.Unreassigned)),
+	)
+
+	return &entity.RemoveTeamMembersResponse{
+		TeamName:            req.TeamName,
+		ReviewReassignments: plan.Reassignments,
+		UnreassignedReviews: plan.Unreassigned,
+	}, nil
+}
+
+func (uc *UseCase) Rename(ctx context.Context, req *entity.RenameTeamRequest) (*entity.Team, error) {
+	ctx, span := tracing.Start(ctx, "team.Rename",
+		attribute.String("team.name", req.TeamName),
+		attribute.String("team.new_name", req.NewTeamName),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
 
-		existingMembers := make([]*entity.Member, 0)
-		newMembers := make([]*entity.Member, 0)
-		for _, member := range team.Members {
-			if member != nil {
-				if _, exists = existingIDs[member.ID]; exists {
-					existingMembers = append(existingMembers, member)
-				} else {
-					newMembers = append(newMembers, member)
-				}
+	var team *entity.Team
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.teamRepo.Rename(ctx, req.TeamName, req.NewTeamName); err != nil {
+			l.Warn("failed to rename team", zap.Error(err))
+			switch {
+			case errors.Is(err, entity.ErrNotFound):
+				return entity.ErrTeamNotFound
+			case errors.Is(err, entity.ErrAlreadyExists):
+				return entity.ErrTeamExists
 			}
+			return err
 		}
 
-		if len(existingMembers) > 0 {
-			if err = uc.userRepo.UpdateMembers(ctx, existingMembers, team.Name); err != nil {
-				l.Warn("failed to update existing users", zap.Error(err))
-				return err
+		members, err := uc.userRepo.GetByTeamName(ctx, req.NewTeamName)
+		if err != nil {
+			l.Warn("failed to get team members", zap.Error(err))
+			return err
+		}
+
+		team = &entity.Team{Name: req.NewTeamName, Members: members}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return team, nil
+}
+
+// Archive closes the team for new PRs while keeping its members, PRs and
+// reviews, and reports the open PRs its members still own.
+func (uc *UseCase) Archive(ctx context.Context, req *entity.ArchiveTeamRequest) (*entity.ArchiveTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "team.Archive", attribute.String("team.name", req.TeamName))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var resp *entity.ArchiveTeamResponse
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		archivedAt, err := uc.teamRepo.Archive(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to archive team", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrTeamNotFound
 			}
+			return err
+		}
+
+		pullRequests, err := uc.pullRequestRepo.GetOpenPullRequestsByTeamName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get open pull requests of team", zap.Error(err))
+			return err
 		}
 
-		if len(newMembers) > 0 {
-			if err = uc.userRepo.CreateBatch(ctx, newMembers, team.Name); err != nil {
-				l.Warn("failed to create new users", zap.Error(err))
+		if len(pullRequests) > 0 {
+			prIDs := make([]string, 0, len(pullRequests))
+			for _, pr := range pullRequests {
+				prIDs = append(prIDs, pr.ID)
+			}
+
+			reviewersMap, err := uc.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+			if err != nil {
+				l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
 				return err
 			}
+			for _, pr := range pullRequests {
+				pr.Reviewers = reviewersMap[pr.ID]
+			}
 		}
 
+		resp = &entity.ArchiveTeamResponse{
+			TeamName:         req.TeamName,
+			ArchivedAt:       archivedAt,
+			OpenPullRequests: pullRequests,
+		}
 		return nil
 	})
 
 	if err != nil {
-		return err
+		tracing.RecordError(span, err)
+		return nil, err
 	}
-	return nil
+
+	span.SetAttributes(attribute.Int("pull_requests.open", len(resp.OpenPullRequests)))
+	return resp, nil
 }
 
-func (uc *UseCase) GetByName(ctx context.Context, name string) (*entity.Team, error) {
+// Rebalance moves open reviews of the team's PRs from its most loaded active
+// members to the least loaded ones. Unless it is a dry run, the moves are
+// applied and written to the review audit log in one transaction.
+func (uc *UseCase) Rebalance(ctx context.Context, req *entity.RebalanceTeamRequest) (*entity.RebalanceTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "team.Rebalance",
+		attribute.String("team.name", req.TeamName),
+		attribute.Bool("dry_run", req.DryRun),
+	)
+	defer span.End()
 	l := logger.FromCtx(ctx)
-	team, err := uc.teamRepo.GetByName(ctx, name)
+
+	var resp *entity.RebalanceTeamResponse
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.checkTeamOpen(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		plan, balance, err := uc.planner.BuildRebalance(ctx, req.TeamName, req.Tolerance)
+		if err != nil {
+			return err
+		}
+
+		if !req.DryRun {
+			if err = uc.planner.Apply(ctx, plan); err != nil {
+				return err
+			}
+			err = uc.pullRequestRepo.AddAuditEvents(ctx, entity.AuditEventRebalance, req.TeamName, plan.Reassignments)
+			if err != nil {
+				l.Warn("failed to add review audit events", zap.Error(err))
+				return err
+			}
+		}
+
+		resp = &entity.RebalanceTeamResponse{
+			TeamName:            req.TeamName,
+			DryRun:              req.DryRun,
+			MeanOpenReviews:     balance.Mean,
+			ReviewReassignments: plan.Reassignments,
+			OpenReviews:         balance.OpenReviews,
+		}
+		return nil
+	})
+
 	if err != nil {
-		l.Warn("failed to get team", zap.Error(err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
-	members, err := uc.userRepo.GetByTeamName(ctx, team.Name)
+	span.SetAttributes(attribute.Int("reviews.moved", len(resp.ReviewReassignments)))
+	return resp, nil
+}
+
+// SetParent attaches the team to a parent team, or detaches it when the parent
+// name is empty. Parents are used as reviewer fallback for their children.
+func (uc *UseCase) SetParent(ctx context.Context, req *entity.SetTeamParentRequest) (*entity.Team, error) {
+	ctx, span := tracing.Start(ctx, "team.SetParent",
+		attribute.String("team.name", req.TeamName),
+		attribute.String("team.parent_name", req.ParentTeamName),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var team *entity.Team
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.checkTeamExists(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		if req.ParentTeamName != "" {
+			if err := uc.checkTeamExists(ctx, req.ParentTeamName); err != nil {
+				return err
+			}
+			if err := uc.checkNoCycle(ctx, req.TeamName, req.ParentTeamName); err != nil {
+				return err
+			}
+		}
+
+		if err := uc.teamRepo.SetParent(ctx, req.TeamName, req.ParentTeamName); err != nil {
+			l.Warn("failed to set parent team", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrTeamNotFound
+			}
+			return err
+		}
+
+		var err error
+		team, err = uc.teamRepo.GetByName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get team", zap.Error(err))
+			return err
+		}
+
+		team.Members, err = uc.userRepo.GetByTeamName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get team members", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+
 	if err != nil {
-		l.Warn("failed to get team members", zap.Error(err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
+	return team, nil
+}
 
-	team.Members = members
+// SetLeadEscalation toggles whether reviews nobody else in the team can take
+// over are handed to the team leads.
+func (uc *UseCase) SetLeadEscalation(ctx context.Context, req *entity.SetLeadEscalationRequest) (*entity.Team, error) {
+	ctx, span := tracing.Start(ctx, "team.SetLeadEscalation",
+		attribute.String("team.name", req.TeamName),
+		attribute.Bool("team.escalate_to_lead", *req.Enabled),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var team *entity.Team
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if err := uc.teamRepo.SetLeadEscalation(ctx, req.TeamName, *req.Enabled); err != nil {
+			l.Warn("failed to set lead escalation", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrTeamNotFound
+			}
+			return err
+		}
+
+		var err error
+		team, err = uc.teamRepo.GetByName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get team", zap.Error(err))
+			return err
+		}
+
+		team.Members, err = uc.userRepo.GetByTeamName(ctx, req.TeamName)
+		if err != nil {
+			l.Warn("failed to get team members", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
 	return team, nil
 }
+
+// checkNoCycle walks up from parent and fails if teamName is one of its
+// ancestors, which would make the hierarchy cyclic.
+func (uc *UseCase) checkNoCycle(ctx context.Context, teamName, parent string) error {
+	l := logger.FromCtx(ctx)
+	for current := parent; current != ""; {
+		if current == teamName {
+			return entity.ErrTeamCycle
+		}
+
+		next, err := uc.teamRepo.GetParentName(ctx, current)
+		if err != nil {
+			l.Warn("failed to get parent team", zap.Error(err))
+			return err
+		}
+		current = next
+	}
+	return nil
+}
+
+func (uc *UseCase) GetSettings(ctx context.Context, teamName string) (*entity.TeamSettings, error) {
+	ctx, span := tracing.Start(ctx, "team.GetSettings", attribute.String("team.name", teamName))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	settings, err := uc.teamRepo.GetSettings(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to get team settings", zap.Error(err))
+		if errors.Is(err, entity.ErrNotFound) {
+			err = entity.ErrTeamNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the team settings if they are still at version, so
+// concurrent editors do not overwrite each other.
+func (uc *UseCase) UpdateSettings(ctx context.Context, req *entity.UpdateTeamSettingsRequest, version int) (*entity.TeamSettings, error) {
+	ctx, span := tracing.Start(ctx, "team.UpdateSettings",
+		attribute.String("team.name", req.TeamName),
+		attribute.Int("team_settings.version", version),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	if *req.ApprovalQuorum > *req.ReviewerCount {
+		return nil, entity.ErrInvalidTeamSettings
+	}
+
+	settings := &entity.TeamSettings{
+		TeamName:               req.TeamName,
+		ReviewerCount:          *req.ReviewerCount,
+		SelectionStrategy:      req.SelectionStrategy,
+		ReviewSLAHours:         *req.ReviewSLAHours,
+		ApprovalQuorum:         *req.ApprovalQuorum,
+		AuthorChoosesReviewers: *req.AuthorChoosesReviewers,
+		CrossTeamFallback:      *req.CrossTeamFallback,
+		MaxOpenReviews:         *req.MaxOpenReviews,
+	}
+
+	var updated *entity.TeamSettings
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.teamRepo.GetSettings(ctx, req.TeamName); err != nil {
+			l.Warn("failed to get team settings", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrTeamNotFound
+			}
+			return err
+		}
+
+		var err error
+		updated, err = uc.teamRepo.UpdateSettings(ctx, settings, version)
+		if err != nil {
+			l.Warn("failed to update team settings", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrSettingsChanged
+			}
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return updated, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase/mocks"
@@ -27,19 +28,20 @@ func (m *testManager) DoWithSettings(ctx context.Context, _ trm.Settings, f func
 	return f(ctx)
 }
 
-func newUseCaseWithMocks(t *testing.T) (*UseCase, *mocks.MockTeamRepository, *mocks.MockUserRepository, *testManager) {
+func newUseCaseWithMocks(t *testing.T) (*UseCase, *mocks.MockTeamRepository, *mocks.MockUserRepository, *mocks.MockPullRequestRepository, *testManager) {
 	t.Helper()
 
 	teamRepo := mocks.NewMockTeamRepository(t)
 	userRepo := mocks.NewMockUserRepository(t)
+	prRepo := mocks.NewMockPullRequestRepository(t)
 	trManager := &testManager{}
 
-	uc := NewUseCase(teamRepo, userRepo, trManager)
-	return uc, teamRepo, userRepo, trManager
+	uc := NewUseCase(teamRepo, userRepo, prRepo, trManager)
+	return uc, teamRepo, userRepo, prRepo, trManager
 }
 
 func TestUseCase_CreateTeam_DuplicateMemberIDs(t *testing.T) {
-	uc, teamRepo, userRepo, trManager := newUseCaseWithMocks(t)
+	uc, teamRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	member1 := &entity.Member{ID: "u1", Username: "user1", IsActive: true}
@@ -61,7 +63,7 @@ func TestUseCase_CreateTeam_DuplicateMemberIDs(t *testing.T) {
 }
 
 func TestUseCase_CreateTeam_TeamAlreadyExists(t *testing.T) {
-	uc, teamRepo, userRepo, trManager := newUseCaseWithMocks(t)
+	uc, teamRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	member := &entity.Member{ID: "u1", Username: "user1", IsActive: true}
@@ -78,6 +80,7 @@ func TestUseCase_CreateTeam_TeamAlreadyExists(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.True(t, errors.Is(err, entity.ErrAlreadyExists))
+	assert.True(t, errors.Is(err, entity.ErrTeamExists))
 	assert.True(t, trManager.doCalled)
 
 	teamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
@@ -85,7 +88,7 @@ func TestUseCase_CreateTeam_TeamAlreadyExists(t *testing.T) {
 }
 
 func TestUseCase_CreateTeam_CheckTeamNameExistsError(t *testing.T) {
-	uc, teamRepo, _, trManager := newUseCaseWithMocks(t)
+	uc, teamRepo, _, _, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	member := &entity.Member{ID: "u1", Username: "user1", IsActive: true}
@@ -108,7 +111,7 @@ func TestUseCase_CreateTeam_CheckTeamNameExistsError(t *testing.T) {
 }
 
 func TestUseCase_CreateTeam_Success(t *testing.T) {
-	uc, teamRepo, userRepo, trManager := newUseCaseWithMocks(t)
+	uc, teamRepo, userRepo, prRepo, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	memberExisting := &entity.Member{ID: "u1", Username: "user1", IsActive: true}
@@ -144,6 +147,10 @@ func TestUseCase_CreateTeam_Success(t *testing.T) {
 		CreateBatch(ctx, []*entity.Member{memberNew}, team.Name).
 		Return(nil)
 
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, ids).
+		Return([]*entity.BacklogCandidates{}, nil)
+
 	err := uc.CreateTeam(ctx, team)
 
 	assert.NoError(t, err)
@@ -204,7 +211,7 @@ func TestUseCase_GetByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			uc, teamRepo, userRepo, _ := newUseCaseWithMocks(t)
+			uc, teamRepo, userRepo, _, _ := newUseCaseWithMocks(t)
 			ctx := context.Background()
 
 			teamRepo.EXPECT().
@@ -231,3 +238,597 @@ func TestUseCase_GetByName(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_AddMembers_TeamNotFound(t *testing.T) {
+	uc, teamRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.AddTeamMembersRequest{
+		TeamName: "unknown-team",
+		Members:  []*entity.Member{{ID: "u1", Username: "user1", IsActive: true}},
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(false, nil)
+
+	team, err := uc.AddMembers(ctx, req)
+
+	assert.Nil(t, team)
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
+	assert.True(t, trManager.doCalled)
+
+	userRepo.AssertNotCalled(t, "FindExistingByIDs", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_AddMembers_Success(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	memberNew := &entity.Member{ID: "u3", Username: "user3", IsActive: true}
+	req := &entity.AddTeamMembersRequest{
+		TeamName: "team-1",
+		Members:  []*entity.Member{memberNew},
+	}
+	members := []*entity.Member{
+		{ID: "u1", Username: "user1", IsActive: true},
+		memberNew,
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		FindExistingByIDs(ctx, []string{"u3"}).
+		Return(map[string]struct{}{}, nil)
+
+	userRepo.EXPECT().
+		CreateBatch(ctx, []*entity.Member{memberNew}, req.TeamName).
+		Return(nil)
+
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{"u3"}).
+		Return([]*entity.BacklogCandidates{}, nil)
+
+	userRepo.EXPECT().
+		GetByTeamName(ctx, req.TeamName).
+		Return(members, nil)
+
+	team, err := uc.AddMembers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, req.TeamName, team.Name)
+	assert.Equal(t, members, team.Members)
+
+	userRepo.AssertNotCalled(t, "UpdateMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_AddMembers_Secondary(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	existing := &entity.Member{ID: "u2", Username: "user2", IsActive: true}
+	memberNew := &entity.Member{ID: "u3", Username: "user3", IsActive: true}
+	req := &entity.AddTeamMembersRequest{
+		TeamName:  "guild",
+		Members:   []*entity.Member{existing, memberNew},
+		Secondary: true,
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		FindExistingByIDs(ctx, []string{"u2", "u3"}).
+		Return(map[string]struct{}{"u2": {}}, nil)
+
+	userRepo.EXPECT().
+		AddSecondaryMemberships(ctx, req.TeamName, []*entity.Member{existing}).
+		Return(nil)
+
+	userRepo.EXPECT().
+		CreateBatch(ctx, []*entity.Member{memberNew}, req.TeamName).
+		Return(nil)
+
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{"u2", "u3"}).
+		Return([]*entity.BacklogCandidates{}, nil)
+
+	userRepo.EXPECT().
+		GetByTeamName(ctx, req.TeamName).
+		Return(req.Members, nil)
+
+	team, err := uc.AddMembers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, req.Members, team.Members)
+
+	userRepo.AssertNotCalled(t, "UpdateMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_RemoveMembers_NotTeamMember(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RemoveTeamMembersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+	teamRepo.EXPECT().
+		IsArchived(ctx, req.TeamName).
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: "team-2"}}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]string{}, nil)
+
+	resp, err := uc.RemoveMembers(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrNotTeamMember))
+
+	userRepo.AssertNotCalled(t, "RemoveFromTeam", mock.Anything, mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "GetTeamReviewsByReviewerIDs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_RemoveMembers_ReassignsReviews(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RemoveTeamMembersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+	review := &entity.ReviewRecord{PullRequestID: "pr-1", ReviewerID: "u1"}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+	teamRepo.EXPECT().
+		IsArchived(ctx, req.TeamName).
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"u1"}, nil)
+
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"u2"}, nil)
+
+	prRepo.EXPECT().
+		GetTeamReviewsByReviewerIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]*entity.ReviewRecord{review}, nil)
+
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, mock.Anything).
+		Return(map[string]int{}, nil)
+
+	userRepo.EXPECT().
+		RemoveFromTeam(ctx, req.TeamName, req.UserIDs).
+		Return(nil)
+
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{review}).
+		Return(nil)
+
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+
+	resp, err := uc.RemoveMembers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, req.TeamName, resp.TeamName)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, resp.ReviewReassignments)
+	assert.Empty(t, resp.UnreassignedReviews)
+}
+
+func TestUseCase_RemoveMembers_EscalatesAndQueues(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RemoveTeamMembersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u1"},
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, req.TeamName).Return(false, nil)
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+	userRepo.EXPECT().GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).Return([]string{"u1"}, nil)
+
+	// Nobody else in the team is active, so the lead takes what they can.
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).Return([]string{}, nil)
+	prRepo.EXPECT().GetTeamReviewsByReviewerIDs(ctx, req.TeamName, req.UserIDs).Return(reviews, nil)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, req.TeamName).Return(true, nil)
+	userRepo.EXPECT().GetLeadIDs(ctx, req.TeamName, req.UserIDs).Return([]string{"lead-1"}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1", "pr-2"}).
+		Return(map[string][]string{"pr-1": {"u1"}, "pr-2": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1", "pr-2"}).
+		Return(map[string]string{"pr-1": "u9", "pr-2": "lead-1"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"lead-1"}).
+		Return(map[string]int{}, nil)
+
+	userRepo.EXPECT().RemoveFromTeam(ctx, req.TeamName, req.UserIDs).Return(nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, []*entity.ReviewRecord{reviews[0]}).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "lead-1"}}).
+		Return(nil)
+	prRepo.EXPECT().EnqueueReviewerReplacements(ctx, "pr-2", []string{"u1"}).Return(nil)
+
+	resp, err := uc.RemoveMembers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "lead-1", Escalated: true},
+	}, resp.ReviewReassignments)
+	assert.Equal(t, []*entity.UnreassignedReview{{PullRequestID: "pr-2", ReviewerID: "u1"}}, resp.UnreassignedReviews)
+}
+
+func TestUseCase_RemoveMembers_TeamArchived(t *testing.T) {
+	uc, teamRepo, userRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RemoveTeamMembersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, req.TeamName).Return(true, nil)
+
+	resp, err := uc.RemoveMembers(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrTeamArchived))
+
+	userRepo.AssertNotCalled(t, "RemoveFromTeam", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_Rename_NewNameTaken(t *testing.T) {
+	uc, teamRepo, userRepo, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RenameTeamRequest{TeamName: "team-1", NewTeamName: "team-2"}
+
+	teamRepo.EXPECT().
+		Rename(ctx, req.TeamName, req.NewTeamName).
+		Return(entity.ErrAlreadyExists)
+
+	team, err := uc.Rename(ctx, req)
+
+	assert.Nil(t, team)
+	assert.True(t, errors.Is(err, entity.ErrTeamExists))
+	assert.True(t, trManager.doCalled)
+
+	userRepo.AssertNotCalled(t, "GetByTeamName", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_Rename_Success(t *testing.T) {
+	uc, teamRepo, userRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RenameTeamRequest{TeamName: "team-1", NewTeamName: "team-2"}
+	members := []*entity.Member{{ID: "u1", Username: "user1", IsActive: true}}
+
+	teamRepo.EXPECT().
+		Rename(ctx, req.TeamName, req.NewTeamName).
+		Return(nil)
+
+	userRepo.EXPECT().
+		GetByTeamName(ctx, req.NewTeamName).
+		Return(members, nil)
+
+	team, err := uc.Rename(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Team{Name: req.NewTeamName, Members: members}, team)
+}
+
+func TestUseCase_Archive_TeamNotFound(t *testing.T) {
+	uc, teamRepo, _, prRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ArchiveTeamRequest{TeamName: "unknown-team"}
+
+	teamRepo.EXPECT().
+		Archive(ctx, req.TeamName).
+		Return(time.Time{}, entity.ErrNotFound)
+
+	resp, err := uc.Archive(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrTeamNotFound))
+
+	prRepo.AssertNotCalled(t, "GetOpenPullRequestsByTeamName", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_Archive_ReportsOpenPullRequests(t *testing.T) {
+	uc, teamRepo, _, prRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ArchiveTeamRequest{TeamName: "team-1"}
+	archivedAt := time.Date(2025, 11, 26, 10, 0, 0, 0, time.UTC)
+	openPRs := []*entity.PullRequest{
+		{ID: "pr-1", Name: "PR 1", AuthorID: "u1", Status: entity.StatusOpen},
+	}
+
+	teamRepo.EXPECT().
+		Archive(ctx, req.TeamName).
+		Return(archivedAt, nil)
+
+	prRepo.EXPECT().
+		GetOpenPullRequestsByTeamName(ctx, req.TeamName).
+		Return(openPRs, nil)
+
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u2", "u3"}}, nil)
+
+	resp, err := uc.Archive(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, req.TeamName, resp.TeamName)
+	assert.Equal(t, archivedAt, resp.ArchivedAt)
+	assert.Len(t, resp.OpenPullRequests, 1)
+	assert.Equal(t, []string{"u2", "u3"}, resp.OpenPullRequests[0].Reviewers)
+}
+
+func TestUseCase_SetParent_Cycle(t *testing.T) {
+	uc, teamRepo, _, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.SetTeamParentRequest{TeamName: "engineering", ParentTeamName: "backend"}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "engineering").Return(true, nil)
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "backend").Return(true, nil)
+	teamRepo.EXPECT().GetParentName(ctx, "backend").Return("engineering", nil)
+
+	team, err := uc.SetParent(ctx, req)
+
+	assert.Nil(t, team)
+	assert.True(t, errors.Is(err, entity.ErrTeamCycle))
+	assert.True(t, trManager.doCalled)
+
+	teamRepo.AssertNotCalled(t, "SetParent", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_SetParent_Success(t *testing.T) {
+	uc, teamRepo, userRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.SetTeamParentRequest{TeamName: "backend", ParentTeamName: "engineering"}
+	members := []*entity.Member{{ID: "u1", Username: "user1", IsActive: true}}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "backend").Return(true, nil)
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "engineering").Return(true, nil)
+	teamRepo.EXPECT().GetParentName(ctx, "engineering").Return("", nil)
+	teamRepo.EXPECT().SetParent(ctx, "backend", "engineering").Return(nil)
+	teamRepo.EXPECT().
+		GetByName(ctx, "backend").
+		Return(&entity.Team{Name: "backend", ParentName: "engineering"}, nil)
+	userRepo.EXPECT().GetByTeamName(ctx, "backend").Return(members, nil)
+
+	team, err := uc.SetParent(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Team{Name: "backend", ParentName: "engineering", Members: members}, team)
+}
+
+func newUpdateTeamSettingsRequest(reviewerCount, quorum int) *entity.UpdateTeamSettingsRequest {
+	sla, maxOpen := 24, 5
+	chooses, fallback := true, false
+	return &entity.UpdateTeamSettingsRequest{
+		TeamName:               "backend",
+		ReviewerCount:          &reviewerCount,
+		SelectionStrategy:      entity.StrategyLeastLoaded,
+		ReviewSLAHours:         &sla,
+		ApprovalQuorum:         &quorum,
+		AuthorChoosesReviewers: &chooses,
+		CrossTeamFallback:      &fallback,
+		MaxOpenReviews:         &maxOpen,
+	}
+}
+
+func TestUseCase_UpdateSettings_Success(t *testing.T) {
+	uc, teamRepo, _, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := newUpdateTeamSettingsRequest(3, 2)
+	current := entity.DefaultTeamSettings("backend")
+	current.Version = 4
+
+	teamRepo.EXPECT().GetSettings(ctx, "backend").Return(current, nil)
+	teamRepo.EXPECT().
+		UpdateSettings(ctx, mock.MatchedBy(func(s *entity.TeamSettings) bool {
+			return s.TeamName == "backend" &&
+				s.ReviewerCount == 3 &&
+				s.SelectionStrategy == entity.StrategyLeastLoaded &&
+				s.ReviewSLAHours == 24 &&
+				s.ApprovalQuorum == 2 &&
+				s.AuthorChoosesReviewers &&
+				!s.CrossTeamFallback &&
+				s.MaxOpenReviews == 5
+		}), 4).
+		RunAndReturn(func(_ context.Context, s *entity.TeamSettings, version int) (*entity.TeamSettings, error) {
+			updated := *s
+			updated.Version = version + 1
+			return &updated, nil
+		})
+
+	settings, err := uc.UpdateSettings(ctx, req, 4)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, 5, settings.Version)
+	assert.Equal(t, 3, settings.ReviewerCount)
+}
+
+func TestUseCase_UpdateSettings_VersionChanged(t *testing.T) {
+	uc, teamRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := newUpdateTeamSettingsRequest(2, 1)
+
+	teamRepo.EXPECT().GetSettings(ctx, "backend").Return(entity.DefaultTeamSettings("backend"), nil)
+	teamRepo.EXPECT().UpdateSettings(ctx, mock.Anything, 1).Return(nil, entity.ErrNotFound)
+
+	settings, err := uc.UpdateSettings(ctx, req, 1)
+
+	assert.Nil(t, settings)
+	assert.True(t, errors.Is(err, entity.ErrSettingsChanged))
+}
+
+func TestUseCase_UpdateSettings_TeamNotFound(t *testing.T) {
+	uc, teamRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := newUpdateTeamSettingsRequest(2, 1)
+
+	teamRepo.EXPECT().GetSettings(ctx, "backend").Return(nil, entity.ErrNotFound)
+
+	settings, err := uc.UpdateSettings(ctx, req, 1)
+
+	assert.Nil(t, settings)
+	assert.True(t, errors.Is(err, entity.ErrTeamNotFound))
+	teamRepo.AssertNotCalled(t, "UpdateSettings", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_UpdateSettings_QuorumExceedsReviewers(t *testing.T) {
+	uc, _, _, _, trManager := newUseCaseWithMocks(t)
+
+	settings, err := uc.UpdateSettings(context.Background(), newUpdateTeamSettingsRequest(1, 2), 1)
+
+	assert.Nil(t, settings)
+	assert.True(t, errors.Is(err, entity.ErrInvalidTeamSettings))
+	assert.False(t, trManager.doCalled)
+}
+
+// expectUnevenTeam sets up a team where u1 reviews all three open PRs, while u2
+// and u3 review one each and are below the mean. Reviews on PRs of other teams
+// do not count, so open review counts are never loaded.
+func expectUnevenTeam(ctx context.Context, teamRepo *mocks.MockTeamRepository, userRepo *mocks.MockUserRepository, prRepo *mocks.MockPullRequestRepository) {
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "team-1").Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, "team-1").Return(false, nil)
+
+	userRepo.EXPECT().
+		GetAvailableMemberIDs(ctx, "team-1").
+		Return([]string{"u1", "u2", "u3"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"u1", "u2", "u3"}).
+		Return(map[string]int{}, nil)
+
+	prRepo.EXPECT().
+		GetOpenPullRequestsByTeamName(ctx, "team-1").
+		Return([]*entity.PullRequest{
+			{ID: "pr-1", AuthorID: "u2", Status: entity.StatusOpen},
+			{ID: "pr-2", AuthorID: "u2", Status: entity.StatusOpen},
+			{ID: "pr-3", AuthorID: "u3", Status: entity.StatusOpen},
+		}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1", "pr-2", "pr-3"}).
+		Return(map[string][]string{"pr-1": {"u1", "u3"}, "pr-2": {"u1"}, "pr-3": {"u1", "u2"}}, nil)
+}
+
+func TestUseCase_Rebalance_DryRun(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RebalanceTeamRequest{TeamName: "team-1", DryRun: true}
+	expectUnevenTeam(ctx, teamRepo, userRepo, prRepo)
+
+	resp, err := uc.Rebalance(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.True(t, resp.DryRun)
+	assert.InDelta(t, 5.0/3.0, resp.MeanOpenReviews, 1e-9)
+	// u2 authored or reviews every PR of u1, so the only move left is pr-2 to u3.
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-2", OldReviewerID: "u1", NewReviewerID: "u3"},
+	}, resp.ReviewReassignments)
+	assert.Equal(t, map[string]int{"u1": 2, "u2": 1, "u3": 2}, resp.OpenReviews)
+
+	prRepo.AssertNotCalled(t, "RemoveReviewersBatch", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "AddReviewersBatch", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "AddAuditEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_Rebalance_AppliesAndAudits(t *testing.T) {
+	uc, teamRepo, userRepo, prRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RebalanceTeamRequest{TeamName: "team-1"}
+	expectUnevenTeam(ctx, teamRepo, userRepo, prRepo)
+
+	moves := []*entity.ReviewReassignment{
+		{PullRequestID: "pr-2", OldReviewerID: "u1", NewReviewerID: "u3"},
+	}
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-2", ReviewerID: "u1"}}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-2", ReviewerID: "u3"}}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddAuditEvents(ctx, entity.AuditEventRebalance, "team-1", moves).
+		Return(nil)
+
+	resp, err := uc.Rebalance(ctx, req)
+
+	assert.NoError(t, err)
+	assert.False(t, resp.DryRun)
+	assert.Equal(t, moves, resp.ReviewReassignments)
+}
+
+func TestUseCase_Rebalance_TeamArchived(t *testing.T) {
+	uc, teamRepo, userRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.RebalanceTeamRequest{TeamName: "team-1"}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "team-1").Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, "team-1").Return(true, nil)
+
+	resp, err := uc.Rebalance(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrTeamArchived))
+
+	userRepo.AssertNotCalled(t, "GetAvailableMemberIDs", mock.Anything, mock.Anything)
+}
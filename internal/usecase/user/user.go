@@ -2,13 +2,19 @@ package user
 
 import (
 	"context"
-	"math/rand"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
 	"time"
 
 	"github.com/avito-tech/go-transaction-manager/trm/v2"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/tracing"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase"
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase/reassignment"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
@@ -17,6 +23,7 @@ type UseCase struct {
 	pullRequestRepo usecase.PullRequestRepository
 	teamRepo        usecase.TeamRepository
 	transactor      trm.Manager
+	planner         *reassignment.Planner
 }
 
 func NewUseCase(userRepo usecase.UserRepository, pullRequestRepo usecase.PullRequestRepository, teamRepo usecase.TeamRepository, transactor trm.Manager) *UseCase {
@@ -25,36 +32,867 @@ func NewUseCase(userRepo usecase.UserRepository, pullRequestRepo usecase.PullReq
 		pullRequestRepo: pullRequestRepo,
 		teamRepo:        teamRepo,
 		transactor:      transactor,
+		planner:         reassignment.NewPlanner(userRepo, pullRequestRepo, teamRepo),
 	}
 }
 
-func (uc *UseCase) SetIsActive(ctx context.Context, userID string, isActive bool) (*entity.User, error) {
+// SetIsActive switches the user on or off. A reactivated user fills the backlog
+// and, if req sets a target, takes reviews over from the most loaded teammates.
+// A deactivated user keeps their reviews unless req asks to reassign them.
+func (uc *UseCase) SetIsActive(ctx context.Context, req *entity.SetUserActiveRequest) (*entity.SetUserActiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.SetIsActive",
+		attribute.String("user.id", req.UserID),
+		attribute.Bool("user.is_active", *req.IsActive),
+	)
+	defer span.End()
 	l := logger.FromCtx(ctx)
-	exists, err := uc.userRepo.CheckUserExists(ctx, userID)
+	exists, err := uc.userRepo.CheckUserExists(ctx, req.UserID)
 	if err != nil {
 		l.Warn("failed to check user existence", zap.Error(err))
 		return nil, err
 	}
 	if !exists {
-		return nil, entity.ErrNotFound
+		return nil, entity.ErrUserNotFound
+	}
+
+	resp := &entity.SetUserActiveResponse{}
+	err = uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if !*req.IsActive && req.ReassignOpenReviews {
+			return uc.deactivateWithHandOver(ctx, req.UserID, resp)
+		}
+
+		if err := uc.userRepo.SetIsActive(ctx, req.UserID, *req.IsActive); err != nil {
+			l.Warn("failed to set user active status", zap.Error(err))
+			return err
+		}
+		if !*req.IsActive {
+			return nil
+		}
+
+		if err := uc.pullRequestRepo.CancelReviewerReplacements(ctx, req.UserID); err != nil {
+			l.Warn("failed to cancel reviewer replacements", zap.Error(err))
+			return err
+		}
+
+		assigned, err := uc.planner.Backfill(ctx, []string{req.UserID})
+		if err != nil {
+			return err
+		}
+		span.SetAttributes(attribute.Int("reviews.backfilled", len(assigned)))
+
+		if req.TargetOpenReviews == 0 {
+			return nil
+		}
+		resp.ReviewReassignments, err = uc.takeOverReviews(ctx, req.UserID, req.TargetOpenReviews)
+		return err
+	})
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	resp.User, err = uc.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		l.Warn("failed to get updated user", zap.Error(err))
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// deactivateWithHandOver deactivates the user like MassDeactivateUsers does and
+// fills resp with the reassignment report.
+func (uc *UseCase) deactivateWithHandOver(ctx context.Context, userID string, resp *entity.SetUserActiveResponse) error {
+	l := logger.FromCtx(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get user by ID", zap.Error(err))
+		return err
+	}
+
+	plan, err := uc.planHandOver(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.applyMassDeactivation(ctx, []string{user.ID}, plan); err != nil {
+		return err
+	}
+
+	resp.ReviewReassignments = plan.Reassignments
+	resp.UnreassignedReviews = plan.Unreassigned
+	return nil
+}
+
+// takeOverReviews moves open reviews of the user's primary team to the user
+// until they have target of them.
+func (uc *UseCase) takeOverReviews(ctx context.Context, userID string, target int) ([]*entity.ReviewReassignment, error) {
+	l := logger.FromCtx(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get user by ID", zap.Error(err))
+		return nil, err
+	}
+	if user.TeamName == "" {
+		return make([]*entity.ReviewReassignment, 0), nil
+	}
+
+	plan, err := uc.planner.BuildTakeOver(ctx, userID, user.TeamName, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.planner.Apply(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan.Reassignments, nil
+}
+
+func (uc *UseCase) GetUser(ctx context.Context, userID string) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUser", attribute.String("user.id", userID))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get user by ID", zap.Error(err))
+		if errors.Is(err, entity.ErrNotFound) {
+			err = entity.ErrUserNotFound
+		}
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreateUser adds a user outside of /team/add, optionally as a member of an
+// existing team.
+func (uc *UseCase) CreateUser(ctx context.Context, req *entity.CreateUserRequest) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "user.CreateUser",
+		attribute.String("user.id", req.UserID),
+		attribute.String("team.name", req.TeamName),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	user := &entity.User{
+		ID:             req.UserID,
+		Username:       req.Username,
+		IsActive:       true,
+		TeamName:       req.TeamName,
+		Email:          req.Email,
+		ChatHandle:     req.ChatHandle,
+		Timezone:       req.Timezone,
+		Seniority:      req.Seniority,
+		WorkHoursStart: req.WorkHoursStart,
+		WorkHoursEnd:   req.WorkHoursEnd,
+	}
+	if req.IsActive != nil {
+		user.IsActive = *req.IsActive
+	}
+	if req.MaxOpenReviews > 0 {
+		user.MaxOpenReviews = &req.MaxOpenReviews
+	}
+
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		exists, err := uc.userRepo.CheckUserExists(ctx, req.UserID)
+		if err != nil {
+			l.Warn("failed to check user existence", zap.Error(err))
+			return err
+		}
+		if exists {
+			return entity.ErrUserExists
+		}
+
+		if req.TeamName != "" {
+			if err = uc.checkTeamOpen(ctx, req.TeamName); err != nil {
+				return err
+			}
+		}
+
+		if err = uc.userRepo.Create(ctx, user); err != nil {
+			l.Warn("failed to create user", zap.Error(err))
+			if errors.Is(err, entity.ErrAlreadyExists) {
+				return entity.ErrUserExists
+			}
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateUser changes the profile fields set in the request and keeps the rest.
+func (uc *UseCase) UpdateUser(ctx context.Context, req *entity.UpdateUserRequest) (*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "user.UpdateUser", attribute.String("user.id", req.UserID))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var user *entity.User
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = uc.userRepo.GetUserByID(ctx, req.UserID)
+		if err != nil {
+			l.Warn("failed to get user by ID", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+		if user.DeletedAt != nil {
+			return entity.ErrUserNotFound
+		}
+
+		if req.Username != nil {
+			user.Username = *req.Username
+		}
+		if req.Email != nil {
+			user.Email = *req.Email
+		}
+		if req.ChatHandle != nil {
+			user.ChatHandle = *req.ChatHandle
+		}
+		if req.Timezone != nil {
+			user.Timezone = *req.Timezone
+		}
+		if req.Seniority != nil {
+			user.Seniority = *req.Seniority
+		}
+		if req.WorkHoursStart != nil {
+			user.WorkHoursStart = *req.WorkHoursStart
+		}
+		if req.WorkHoursEnd != nil {
+			user.WorkHoursEnd = *req.WorkHoursEnd
+		}
+		if req.MaxOpenReviews != nil {
+			// 0 drops the personal cap in favour of the team default.
+			user.MaxOpenReviews = req.MaxOpenReviews
+			if *req.MaxOpenReviews == 0 {
+				user.MaxOpenReviews = nil
+			}
+		}
+
+		if err = uc.userRepo.UpdateProfile(ctx, user); err != nil {
+			l.Warn("failed to update user profile", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser soft-deletes the user: their open reviews are handed over like on
+// mass deactivation and their ID is replaced with a pseudonym everywhere.
+func (uc *UseCase) DeleteUser(ctx context.Context, req *entity.DeleteUserRequest) (*entity.DeleteUserResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.DeleteUser",
+		attribute.String("user.id", req.UserID),
+		attribute.Bool("user.erase", req.Erase),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var resp *entity.DeleteUserResponse
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetUserByID(ctx, req.UserID)
+		if err != nil {
+			l.Warn("failed to get user by ID", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+		if user.DeletedAt != nil {
+			return entity.ErrUserNotFound
+		}
+
+		plan, err := uc.planHandOver(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		if err = uc.planner.Apply(ctx, plan); err != nil {
+			return err
+		}
+
+		if err = uc.planner.Queue(ctx, plan); err != nil {
+			return err
+		}
+
+		pseudonym, deletedAt, err := uc.userRepo.Delete(ctx, user.ID, req.Erase)
+		if err != nil {
+			l.Warn("failed to delete user", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+
+		// Reviews nobody could take stay with the user under the new ID.
+		for _, review := range plan.Unreassigned {
+			review.ReviewerID = pseudonym
+		}
+
+		resp = &entity.DeleteUserResponse{
+			UserID:              user.ID,
+			PseudonymID:         pseudonym,
+			Erased:              req.Erase,
+			DeletedAt:           deletedAt,
+			ReviewReassignments: plan.Reassignments,
+			UnreassignedReviews: plan.Unreassigned,
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Int("reviews.reassigned", len(resp.ReviewReassignments)),
+		attribute.Int("reviews.unreassigned", len(resp.UnreassignedReviews)),
+	)
+
+	return resp, nil
+}
+
+func (uc *UseCase) SearchUsers(ctx context.Context, req *entity.SearchUsersRequest) (*entity.SearchUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.SearchUsers",
+		attribute.Int("search.limit", req.Limit),
+		attribute.Int("search.offset", req.Offset),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	// One extra row tells whether there is a next page.
+	users, err := uc.userRepo.Search(ctx, req.Query, req.Limit+1, req.Offset)
+	if err != nil {
+		l.Warn("failed to search users", zap.Error(err))
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	resp := &entity.SearchUsersResponse{
+		Users:  users,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	if len(users) > req.Limit {
+		resp.Users = users[:req.Limit]
+		resp.HasMore = true
+	}
+	return resp, nil
+}
+
+func (uc *UseCase) AddUnavailability(ctx context.Context, req *entity.AddUnavailabilityRequest) (*entity.Unavailability, error) {
+	ctx, span := tracing.Start(ctx, "user.AddUnavailability", attribute.String("user.id", req.UserID))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	unavailability := &entity.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	}
+
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.getLiveUser(ctx, req.UserID); err != nil {
+			return err
+		}
+
+		if err := uc.userRepo.AddUnavailability(ctx, unavailability); err != nil {
+			l.Warn("failed to add unavailability", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return unavailability, nil
+}
+
+func (uc *UseCase) GetUnavailability(ctx context.Context, userID string) (*entity.UserUnavailabilityResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUnavailability", attribute.String("user.id", userID))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	if _, err := uc.getLiveUser(ctx, userID); err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	entries, err := uc.userRepo.GetUnavailabilityByUserID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get unavailability", zap.Error(err))
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return &entity.UserUnavailabilityResponse{UserID: userID, Unavailability: entries}, nil
+}
+
+// RemoveUnavailability deletes the entry and returns the remaining absences of
+// the user.
+func (uc *UseCase) RemoveUnavailability(ctx context.Context, req *entity.RemoveUnavailabilityRequest) (*entity.UserUnavailabilityResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.RemoveUnavailability",
+		attribute.String("user.id", req.UserID),
+		attribute.Int64("unavailability.id", req.ID),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var entries []*entity.Unavailability
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		err := uc.userRepo.RemoveUnavailability(ctx, req.UserID, req.ID)
+		if err != nil {
+			l.Warn("failed to remove unavailability", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrAbsenceNotFound
+			}
+			return err
+		}
+
+		entries, err = uc.userRepo.GetUnavailabilityByUserID(ctx, req.UserID)
+		if err != nil {
+			l.Warn("failed to get unavailability", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	return &entity.UserUnavailabilityResponse{UserID: req.UserID, Unavailability: entries}, nil
+}
+
+// ReassignReviewsOfAbsentUsers hands open reviews of users whose absence of at
+// least minDays has started over to their teammates, once per absence. Every
+// absence is handled in its own transaction, so one failing handover does not
+// hold back the others. It returns the number of absences handled.
+func (uc *UseCase) ReassignReviewsOfAbsentUsers(ctx context.Context, minDays int) (int, error) {
+	ctx, span := tracing.Start(ctx, "user.ReassignReviewsOfAbsentUsers", attribute.Int("absence.min_days", minDays))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var (
+		handled int
+		errs    []error
+	)
+	// failed is never nil: a NULL array would make the claim match nothing.
+	failed := make([]int64, 0)
+	// Overlapping absences of one user hand the reviews over once per run.
+	seen := make(map[string]struct{})
+	for {
+		var absence *entity.Unavailability
+		err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+			var err error
+			absence, err = uc.userRepo.ClaimStartedAbsence(ctx, minDays, failed)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := seen[absence.UserID]; !ok {
+				if err = uc.handOverReviewsOfAbsentUser(ctx, absence.UserID); err != nil {
+					return err
+				}
+			}
+
+			if err = uc.userRepo.MarkAbsencesHandled(ctx, []int64{absence.ID}); err != nil {
+				l.Warn("failed to mark absence handled", zap.Error(err))
+				return err
+			}
+			return nil
+		})
+
+		if absence == nil {
+			if err != nil && !errors.Is(err, entity.ErrNotFound) {
+				l.Warn("failed to claim started absence", zap.Error(err))
+				errs = append(errs, err)
+			}
+			break
+		}
+		if err != nil {
+			l.Warn("failed to hand over reviews of absent user",
+				zap.Int64("absence_id", absence.ID),
+				zap.String("user_id", absence.UserID),
+				zap.Error(err),
+			)
+			failed = append(failed, absence.ID)
+			errs = append(errs, err)
+			continue
+		}
+
+		seen[absence.UserID] = struct{}{}
+		handled++
+	}
+
+	span.SetAttributes(attribute.Int("absences.handled", handled))
+	if err := errors.Join(errs...); err != nil {
+		tracing.RecordError(span, err)
+		return handled, err
+	}
+	return handled, nil
+}
+
+func (uc *UseCase) handOverReviewsOfAbsentUser(ctx context.Context, userID string) error {
+	ctx, span := tracing.Start(ctx, "user.handOverReviewsOfAbsentUser", attribute.String("user.id", userID))
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get user by ID", zap.Error(err))
+		return err
+	}
+
+	plan, err := uc.planHandOver(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if err = uc.planner.Apply(ctx, plan); err != nil {
+		return err
+	}
+	if err = uc.planner.Queue(ctx, plan); err != nil {
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Int("reviews.reassigned", len(plan.Reassignments)),
+		attribute.Int("reviews.unreassigned", len(plan.Unreassigned)),
+	)
+	l.Info("handed over reviews of absent user",
+		zap.String("user_id", user.ID),
+		zap.Int("reassigned", len(plan.Reassignments)),
+		zap.Int("unreassigned", len(plan.Unreassigned)),
+	)
+	return nil
+}
+
+// planHandOver plans handing open reviews of the user over to active members
+// of their primary team, escalating to the leads what nobody can take.
+func (uc *UseCase) planHandOver(ctx context.Context, user *entity.User) (*reassignment.Plan, error) {
+	return uc.planner.PlanHandOver(ctx, user.TeamName, []string{user.ID}, false)
+}
+
+// getLiveUser returns the user unless they do not exist or were deleted.
+func (uc *UseCase) getLiveUser(ctx context.Context, userID string) (*entity.User, error) {
+	l := logger.FromCtx(ctx)
+
+	user, err := uc.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		l.Warn("failed to get user by ID", zap.Error(err))
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, entity.ErrUserNotFound
+		}
+		return nil, err
+	}
+	if user.DeletedAt != nil {
+		return nil, entity.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// checkTeamOpen fails unless teamName exists and is not archived.
+func (uc *UseCase) checkTeamOpen(ctx context.Context, teamName string) error {
+	l := logger.FromCtx(ctx)
+
+	exists, err := uc.teamRepo.CheckTeamNameExists(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check team existence", zap.Error(err))
+		return err
+	}
+	if !exists {
+		return entity.ErrTeamNotFound
+	}
+
+	archived, err := uc.teamRepo.IsArchived(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check if team is archived", zap.Error(err))
+		return err
+	}
+	if archived {
+		return entity.ErrTeamArchived
+	}
+	return nil
+}
+
+// HandoverReviews moves open reviews of one user to a chosen colleague in one
+// transaction, newest PRs first. The colleague must be active and not away.
+// PRs the colleague authored or already reviews, and those beyond the
+// colleague's cap of open reviews, stay with the user and are reported as
+// skipped. Moves are written to the review audit log under the primary team
+// of the user, or of the colleague if the user has none.
+func (uc *UseCase) HandoverReviews(ctx context.Context, req *entity.HandoverReviewsRequest) (*entity.HandoverReviewsResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.HandoverReviews",
+		attribute.String("user.from_id", req.FromUserID),
+		attribute.String("user.to_id", req.ToUserID),
+		attribute.String("scope", req.Scope),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	scope := req.Scope
+	if scope == "" {
+		scope = entity.HandoverScopeAll
 	}
 
-	err = uc.userRepo.SetIsActive(ctx, userID, isActive)
+	var resp *entity.HandoverReviewsResponse
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		from, err := uc.getLiveUser(ctx, req.FromUserID)
+		if err != nil {
+			return err
+		}
+
+		to, err := uc.getLiveUser(ctx, req.ToUserID)
+		if err != nil {
+			return err
+		}
+		if !to.IsActive {
+			return entity.ErrInactiveTarget
+		}
+
+		unavailable, err := uc.userRepo.IsUnavailable(ctx, to.ID)
+		if err != nil {
+			l.Warn("failed to check user availability", zap.Error(err))
+			return err
+		}
+		if unavailable {
+			return entity.ErrUnavailableTarget
+		}
+
+		pullRequests, err := uc.openReviewsInScope(ctx, from, scope)
+		if err != nil {
+			return err
+		}
+
+		resp = &entity.HandoverReviewsResponse{
+			FromUserID: from.ID,
+			ToUserID:   to.ID,
+			Scope:      scope,
+			Moved:      make([]*entity.ReviewReassignment, 0),
+			Skipped:    make([]*entity.SkippedReview, 0),
+		}
+		if len(pullRequests) == 0 {
+			return nil
+		}
+
+		prIDs := make([]string, 0, len(pullRequests))
+		for _, pr := range pullRequests {
+			prIDs = append(prIDs, pr.ID)
+		}
+
+		reviewersMap, err := uc.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+		if err != nil {
+			l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
+			return err
+		}
+
+		capacities, err := uc.userRepo.GetReviewCapacities(ctx, []string{to.ID})
+		if err != nil {
+			l.Warn("failed to get review capacities", zap.Error(err))
+			return err
+		}
+		left, capped := capacities[to.ID]
+
+		for _, pr := range pullRequests {
+			if pr.AuthorID == to.ID {
+				resp.Skipped = append(resp.Skipped, &entity.SkippedReview{PullRequestID: pr.ID, Reason: entity.SkipReasonAuthor})
+				continue
+			}
+			if slices.Contains(reviewersMap[pr.ID], to.ID) {
+				resp.Skipped = append(resp.Skipped, &entity.SkippedReview{PullRequestID: pr.ID, Reason: entity.SkipReasonReviewer})
+				continue
+			}
+			if capped && left <= 0 {
+				resp.Skipped = append(resp.Skipped, &entity.SkippedReview{PullRequestID: pr.ID, Reason: entity.SkipReasonCap})
+				continue
+			}
+			resp.Moved = append(resp.Moved, &entity.ReviewReassignment{
+				PullRequestID: pr.ID,
+				OldReviewerID: from.ID,
+				NewReviewerID: to.ID,
+			})
+			left--
+		}
+
+		if err = uc.planner.Apply(ctx, reassignment.PlanOf(resp.Moved, nil)); err != nil {
+			return err
+		}
+
+		auditTeam := from.TeamName
+		if auditTeam == "" {
+			auditTeam = to.TeamName
+		}
+		if auditTeam == "" {
+			return nil
+		}
+		if err = uc.pullRequestRepo.AddAuditEvents(ctx, entity.AuditEventHandover, auditTeam, resp.Moved); err != nil {
+			l.Warn("failed to add audit events", zap.Error(err))
+			return err
+		}
+		return nil
+	})
+
 	if err != nil {
-		l.Warn("failed to set user active status", zap.Error(err))
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
-	updatedUser, err := uc.userRepo.GetUserByID(ctx, userID)
+	span.SetAttributes(
+		attribute.Int("reviews.moved", len(resp.Moved)),
+		attribute.Int("reviews.skipped", len(resp.Skipped)),
+	)
+	return resp, nil
+}
+
+// openReviewsInScope returns open PRs the user reviews, newest first. With the
+// team scope only PRs authored by members of the user's primary team count.
+func (uc *UseCase) openReviewsInScope(ctx context.Context, user *entity.User, scope string) ([]*entity.PullRequest, error) {
+	l := logger.FromCtx(ctx)
+
+	if scope == entity.HandoverScopeTeam && user.TeamName == "" {
+		return nil, entity.ErrNoTeamForScope
+	}
+
+	pullRequests, err := uc.pullRequestRepo.GetPullRequestsByReviewerID(ctx, user.ID)
 	if err != nil {
-		l.Warn("failed to get updated user", zap.Error(err))
+		l.Warn("failed to get pull requests by reviewer ID", zap.Error(err))
+		return nil, err
+	}
+
+	var inScope map[string]struct{}
+	if scope == entity.HandoverScopeTeam {
+		records, err := uc.pullRequestRepo.GetTeamReviewsByReviewerIDs(ctx, user.TeamName, []string{user.ID})
+		if err != nil {
+			l.Warn("failed to get team reviews by reviewer IDs", zap.Error(err))
+			return nil, err
+		}
+		inScope = make(map[string]struct{}, len(records))
+		for _, record := range records {
+			inScope[record.PullRequestID] = struct{}{}
+		}
+	}
+
+	open := make([]*entity.PullRequest, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		if pr.Status != entity.StatusOpen {
+			continue
+		}
+		if _, ok := inScope[pr.ID]; inScope != nil && !ok {
+			continue
+		}
+		open = append(open, pr)
+	}
+	return open, nil
+}
+
+// ChangeTeam moves the user to another team and hands their open reviews over
+// to the active members of the team they leave.
+func (uc *UseCase) ChangeTeam(ctx context.Context, req *entity.ChangeUserTeamRequest) (*entity.ChangeUserTeamResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.ChangeTeam",
+		attribute.String("user.id", req.UserID),
+		attribute.String("team.name", req.TeamName),
+	)
+	defer span.End()
+	l := logger.FromCtx(ctx)
+
+	var resp *entity.ChangeUserTeamResponse
+	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetUserByID(ctx, req.UserID)
+		if err != nil {
+			l.Warn("failed to get user by ID", zap.Error(err))
+			if errors.Is(err, entity.ErrNotFound) {
+				return entity.ErrUserNotFound
+			}
+			return err
+		}
+		if user.DeletedAt != nil {
+			return entity.ErrUserNotFound
+		}
+
+		if err = uc.checkTeamOpen(ctx, req.TeamName); err != nil {
+			return err
+		}
+
+		resp = &entity.ChangeUserTeamResponse{
+			OldTeamName:         user.TeamName,
+			ReviewReassignments: make([]*entity.ReviewReassignment, 0),
+			UnreassignedReviews: make([]*entity.UnreassignedReview, 0),
+		}
+
+		if user.TeamName == req.TeamName {
+			resp.User = user
+			return nil
+		}
+
+		userIDs := []string{user.ID}
+		plan, err := uc.planner.PlanHandOver(ctx, user.TeamName, userIDs, true)
+		if err != nil {
+			return err
+		}
+
+		member := &entity.Member{ID: user.ID, Username: user.Username, IsActive: user.IsActive}
+		if err = uc.userRepo.UpdateMembers(ctx, []*entity.Member{member}, req.TeamName); err != nil {
+			l.Warn("failed to move user to team", zap.Error(err))
+			return err
+		}
+
+		if err = uc.planner.Apply(ctx, plan); err != nil {
+			return err
+		}
+		if err = uc.planner.Queue(ctx, plan); err != nil {
+			return err
+		}
+
+		if _, err = uc.planner.Backfill(ctx, userIDs); err != nil {
+			return err
+		}
+
+		user.TeamName = req.TeamName
+		resp.User = user
+		resp.ReviewReassignments = plan.Reassignments
+		resp.UnreassignedReviews = plan.Unreassigned
+		return nil
+	})
+
+	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
-	return updatedUser, nil
+	span.SetAttributes(
+		attribute.Int("reviews.reassigned", len(resp.ReviewReassignments)),
+		attribute.Int("reviews.unreassigned", len(resp.UnreassignedReviews)),
+	)
+
+	return resp, nil
 }
 
 func (uc *UseCase) GetReviewList(ctx context.Context, userID string) (*entity.UserReviewListResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.GetReviewList", attribute.String("user.id", userID))
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	user, err := uc.userRepo.CheckUserExists(ctx, userID)
 	if err != nil {
@@ -63,7 +901,7 @@ func (uc *UseCase) GetReviewList(ctx context.Context, userID string) (*entity.Us
 	}
 
 	if !user {
-		return nil, entity.ErrNotFound
+		return nil, entity.ErrUserNotFound
 	}
 
 	pullRequests, err := uc.pullRequestRepo.GetPullRequestsByReviewerID(ctx, userID)
@@ -81,6 +919,8 @@ func (uc *UseCase) GetReviewList(ctx context.Context, userID string) (*entity.Us
 }
 
 func (uc *UseCase) GetStatistics(ctx context.Context) (*entity.StatsByUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.GetStatistics")
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	usersIDs, err := uc.userRepo.GetAllUsersIDs(ctx)
 	if err != nil {
@@ -125,19 +965,6 @@ func (uc *UseCase) GetStatistics(ctx context.Context) (*entity.StatsByUsersRespo
 	return &entity.StatsByUsersResponse{UsersStat: statsMap}, nil
 }
 
-func checkUsersSameTeam(users []*entity.User) bool {
-	if len(users) == 0 {
-		return true
-	}
-	teamName := users[0].TeamName
-	for _, user := range users[1:] {
-		if user.TeamName != teamName {
-			return false
-		}
-	}
-	return true
-}
-
 func checkUserIDsUnique(ids []string) bool {
 	idsMap := make(map[string]struct{})
 	for _, id := range ids {
@@ -151,32 +978,50 @@ func checkUserIDsUnique(ids []string) bool {
 	return true
 }
 
+// deactivationPlanTTL is how long a mass deactivation previewed with dry_run
+// can be applied by its token.
+const deactivationPlanTTL = 15 * time.Minute
+
 func (uc *UseCase) MassDeactivateUsers(ctx context.Context, req *entity.MassDeactivateUsersRequest) (*entity.MassDeactivateUsersResponse, error) {
+	ctx, span := tracing.Start(ctx, "user.MassDeactivateUsers",
+		attribute.String("team.name", req.TeamName),
+		attribute.Int("users.count", len(req.UserIDs)),
+		attribute.Bool("dry_run", req.DryRun),
+	)
+	defer span.End()
+
 	if !checkUserIDsUnique(req.UserIDs) {
 		return nil, entity.ErrDuplicateUserIDs
 	}
 
-	reviewReassignments := make([]*entity.ReviewReassignment, 0)
-	unreassignedReviews := make([]*entity.UnreassignedReview, 0)
+	var plan *reassignment.Plan
+	var teams []*entity.TeamDeactivation
+	var preview *entity.DeactivationPlan
 
 	err := uc.transactor.Do(ctx, func(ctx context.Context) error {
-		if _, err := uc.validateTeamAndUsers(ctx, req.TeamName, req.UserIDs); err != nil {
+		groups, err := uc.groupUsersByTeam(ctx, req)
+		if err != nil {
 			return err
 		}
 
-		candidateIDs, excludeSet, err := uc.getCandidateReviewerIDs(ctx, req.TeamName, req.UserIDs)
-		if err != nil {
+		if req.PlanToken != "" {
+			if plan, teams, err = uc.takePreviewedPlan(ctx, req); err != nil {
+				return err
+			}
+			return uc.applyMassDeactivation(ctx, req.UserIDs, plan)
+		}
+
+		teams = groups
+		if plan, err = uc.planMassDeactivation(ctx, req.UserIDs, teams); err != nil {
 			return err
 		}
 
-		var oldReviewsToRemove []*entity.ReviewRecord
-		var newReviewsToAdd []*entity.ReviewRecord
-		reviewReassignments, unreassignedReviews, oldReviewsToRemove, newReviewsToAdd, err = uc.buildReviewReassignmentPlan(ctx, candidateIDs, excludeSet, req.UserIDs)
-		if err != nil {
+		if req.DryRun {
+			preview, err = uc.savePreviewedPlan(ctx, req, plan, teams)
 			return err
 		}
 
-		if err = uc.applyMassDeactivation(ctx, req.UserIDs, oldReviewsToRemove, newReviewsToAdd); err != nil {
+		if err = uc.applyMassDeactivation(ctx, req.UserIDs, plan); err != nil {
 			return err
 		}
 
@@ -184,235 +1029,328 @@ func (uc *UseCase) MassDeactivateUsers(ctx context.Context, req *entity.MassDeac
 	})
 
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, err
 	}
 
-	return &entity.MassDeactivateUsersResponse{
+	span.SetAttributes(
+		attribute.Int("teams.count", len(teams)),
+		attribute.Int("reviews.reassigned", len(plan.Reassignments)),
+		attribute.Int("reviews.unreassigned", len(plan.Unreassigned)),
+	)
+
+	resp := &entity.MassDeactivateUsersResponse{
 		TeamName:            req.TeamName,
-		ReviewReassignments: reviewReassignments,
-		UnreassignedReviews: unreassignedReviews,
-	}, nil
+		ReviewReassignments: plan.Reassignments,
+		UnreassignedReviews: plan.Unreassigned,
+	}
+	if req.TeamName == "" {
+		resp.Teams = teams
+	}
+	if preview != nil {
+		resp.DryRun = true
+		resp.PlanToken = preview.Token
+		resp.PlanExpiresAt = &preview.ExpiresAt
+	}
+	return resp, nil
 }
 
-func (uc *UseCase) validateTeamAndUsers(ctx context.Context, teamName string, userIDs []string) ([]*entity.User, error) {
+// groupUsersByTeam splits the users of the request into the teams their
+// reviews are handed over in: the team from the request, or else the primary
+// team of every user, in the order the users come in.
+func (uc *UseCase) groupUsersByTeam(ctx context.Context, req *entity.MassDeactivateUsersRequest) ([]*entity.TeamDeactivation, error) {
 	l := logger.FromCtx(ctx)
-	exists, err := uc.teamRepo.CheckTeamNameExists(ctx, teamName)
-	if err != nil {
-		l.Warn("failed to check team existence", zap.Error(err))
-		return nil, err
-	}
-	if !exists {
-		l.Warn("team does not exist", zap.String("teamName", teamName))
-		return nil, entity.ErrNotFound
+
+	if req.TeamName != "" {
+		if _, err := uc.validateTeamAndUsers(ctx, req.TeamName, req.UserIDs); err != nil {
+			return nil, err
+		}
+		return []*entity.TeamDeactivation{{TeamName: req.TeamName, UserIDs: req.UserIDs}}, nil
 	}
 
-	users, err := uc.userRepo.GetUsersByIDs(ctx, userIDs)
+	users, err := uc.userRepo.GetUsersByIDs(ctx, req.UserIDs)
 	if err != nil {
 		l.Warn("failed to get users by IDs", zap.Error(err))
 		return nil, err
 	}
-
-	if len(users) == 0 {
-		l.Warn("no users found for deactivation", zap.String("teamName", teamName))
-		return nil, entity.ErrNotFound
+	if len(users) != len(req.UserIDs) {
+		return nil, entity.ErrUserNotFound
 	}
 
-	if !checkUsersSameTeam(users) {
-		l.Warn("users are not from the same team")
-		return nil, entity.ErrUsersNotInSameTeam
+	teamByUser := make(map[string]string, len(users))
+	for _, user := range users {
+		if user.TeamName == "" {
+			return nil, entity.ErrNoPrimaryTeam
+		}
+		teamByUser[user.ID] = user.TeamName
 	}
 
-	if users[0].TeamName != teamName {
-		l.Warn("users team does not match request team", zap.String("teamName", teamName), zap.String("usersTeam", users[0].TeamName))
-		return nil, entity.ErrUsersNotInSameTeam
+	groups := make([]*entity.TeamDeactivation, 0)
+	byTeam := make(map[string]*entity.TeamDeactivation)
+	for _, id := range req.UserIDs {
+		teamName := teamByUser[id]
+		group, ok := byTeam[teamName]
+		if !ok {
+			group = &entity.TeamDeactivation{TeamName: teamName}
+			byTeam[teamName] = group
+			groups = append(groups, group)
+		}
+		group.UserIDs = append(group.UserIDs, id)
 	}
+	return groups, nil
+}
 
-	return users, nil
+// planMassDeactivation plans handing reviews of every group over to its team
+// and fills in the moves of the groups. Each team's plan is built on top of
+// the previous ones, so they can be applied together. No review goes to any
+// of userIDs.
+func (uc *UseCase) planMassDeactivation(ctx context.Context, userIDs []string, groups []*entity.TeamDeactivation) (*reassignment.Plan, error) {
+	plan := reassignment.PlanOf(nil, nil)
+	for _, group := range groups {
+		candidateIDs, excludeSet, err := uc.planner.CandidateIDs(ctx, group.TeamName, userIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		teamPlan, err := uc.planner.BuildAfter(ctx, plan, candidateIDs, excludeSet, group.UserIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = uc.planner.EscalateToLeads(ctx, group.TeamName, userIDs, teamPlan); err != nil {
+			return nil, err
+		}
+
+		group.ReviewReassignments = teamPlan.Reassignments
+		group.UnreassignedReviews = teamPlan.Unreassigned
+		plan.Append(teamPlan)
+	}
+	return plan, nil
 }
 
-func (uc *UseCase) getCandidateReviewerIDs(ctx context.Context, teamName string, userIDs []string) ([]string, map[string]struct{}, error) {
+// savePreviewedPlan stores the plan of a dry run under a new token together
+// with the fingerprint of the reviews it was built from.
+func (uc *UseCase) savePreviewedPlan(
+	ctx context.Context,
+	req *entity.MassDeactivateUsersRequest,
+	plan *reassignment.Plan,
+	teams []*entity.TeamDeactivation,
+) (*entity.DeactivationPlan, error) {
 	l := logger.FromCtx(ctx)
-	candidateIDs, err := uc.userRepo.GetActiveUsersIDsByTeamName(ctx, teamName, userIDs)
+
+	fingerprint, err := uc.pullRequestRepo.GetReviewsFingerprint(ctx, req.UserIDs)
 	if err != nil {
-		l.Warn("failed to get active users by team name", zap.Error(err))
-		return nil, nil, err
+		l.Warn("failed to get reviews fingerprint", zap.Error(err))
+		return nil, err
 	}
 
-	excludeSet := make(map[string]struct{}, len(userIDs))
-	for _, id := range userIDs {
-		excludeSet[id] = struct{}{}
+	token := make([]byte, 16)
+	if _, err = rand.Read(token); err != nil {
+		return nil, err
 	}
 
-	if len(candidateIDs) == 0 {
-		return candidateIDs, excludeSet, nil
+	preview := &entity.DeactivationPlan{
+		Token:         hex.EncodeToString(token),
+		TeamName:      req.TeamName,
+		UserIDs:       req.UserIDs,
+		Fingerprint:   fingerprint,
+		Reassignments: plan.Reassignments,
+		Unreassigned:  plan.Unreassigned,
+		Teams:         teams,
+		ExpiresAt:     time.Now().Add(deactivationPlanTTL).UTC(),
 	}
-
-	filteredCandidates := make([]string, 0, len(candidateIDs))
-	for _, id := range candidateIDs {
-		if _, excluded := excludeSet[id]; !excluded {
-			filteredCandidates = append(filteredCandidates, id)
-		}
+	if err = uc.userRepo.SaveDeactivationPlan(ctx, preview); err != nil {
+		l.Warn("failed to save deactivation plan", zap.Error(err))
+		return nil, err
 	}
-
-	return filteredCandidates, excludeSet, nil
+	return preview, nil
 }
 
-func (uc *UseCase) buildReviewReassignmentPlan(
+// takePreviewedPlan returns the plan previewed for the same team and users and
+// its per-team breakdown, as long as none of the reviews it was built from
+// have changed since and its new reviewers can still take them.
+func (uc *UseCase) takePreviewedPlan(
 	ctx context.Context,
-	candidateIDs []string,
-	excludeSet map[string]struct{},
-	reviewerIDs []string,
-) ([]*entity.ReviewReassignment, []*entity.UnreassignedReview, []*entity.ReviewRecord, []*entity.ReviewRecord, error) {
+	req *entity.MassDeactivateUsersRequest,
+) (*reassignment.Plan, []*entity.TeamDeactivation, error) {
 	l := logger.FromCtx(ctx)
-	reviewReassignments := make([]*entity.ReviewReassignment, 0)
-	unreassignedReviews := make([]*entity.UnreassignedReview, 0)
-	oldReviewsToRemove := make([]*entity.ReviewRecord, 0)
-	newReviewsToAdd := make([]*entity.ReviewRecord, 0)
 
-	reviewRecords, err := uc.pullRequestRepo.GetReviewsByReviewerIDs(ctx, reviewerIDs)
+	preview, err := uc.userRepo.TakeDeactivationPlan(ctx, req.PlanToken)
 	if err != nil {
-		l.Warn("failed to get reviews by reviewer IDs", zap.Error(err))
-		return nil, nil, nil, nil, err
+		l.Warn("failed to take deactivation plan", zap.Error(err))
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, nil, entity.ErrPlanNotFound
+		}
+		return nil, nil, err
 	}
 
-	if len(reviewRecords) == 0 {
-		return reviewReassignments, unreassignedReviews, oldReviewsToRemove, newReviewsToAdd, nil
+	if preview.TeamName != req.TeamName || !sameIDs(preview.UserIDs, req.UserIDs) {
+		return nil, nil, entity.ErrPlanMismatch
 	}
 
-	if len(candidateIDs) == 0 {
-		for _, record := range reviewRecords {
-			if record == nil {
-				continue
-			}
-			unreassignedReviews = append(unreassignedReviews, &entity.UnreassignedReview{
-				PullRequestID: record.PullRequestID,
-				ReviewerID:    record.ReviewerID,
-			})
-		}
-		return reviewReassignments, unreassignedReviews, oldReviewsToRemove, newReviewsToAdd, nil
+	fingerprint, err := uc.pullRequestRepo.GetReviewsFingerprint(ctx, req.UserIDs)
+	if err != nil {
+		l.Warn("failed to get reviews fingerprint", zap.Error(err))
+		return nil, nil, err
 	}
-
-	prIDSet := make(map[string]struct{})
-	for _, record := range reviewRecords {
-		if record != nil {
-			prIDSet[record.PullRequestID] = struct{}{}
-		}
+	if fingerprint != preview.Fingerprint {
+		return nil, nil, entity.ErrPlanOutdated
 	}
 
-	prIDs := make([]string, 0, len(prIDSet))
-	for id := range prIDSet {
-		prIDs = append(prIDs, id)
+	teams := preview.Teams
+	if len(teams) == 0 {
+		// Plans saved before the per-team breakdown cover the request team only.
+		teams = []*entity.TeamDeactivation{{TeamName: preview.TeamName, ReviewReassignments: preview.Reassignments}}
 	}
-
-	reviewersMap, err := uc.pullRequestRepo.GetReviewersByPullRequestIDs(ctx, prIDs)
+	available, err := uc.reviewersAvailable(ctx, req.UserIDs, teams)
 	if err != nil {
-		l.Warn("failed to get reviewers by pull request IDs", zap.Error(err))
-		return nil, nil, nil, nil, err
+		return nil, nil, err
 	}
+	if !available {
+		return nil, nil, entity.ErrPlanOutdated
+	}
+
+	return reassignment.PlanOf(preview.Reassignments, preview.Unreassigned), preview.Teams, nil
+}
+
+// reviewersAvailable reports whether every new reviewer of teams can still
+// take the reviews planned for them: picked reviewers must be active,
+// available members of their team, escalated ones must still be leads who
+// could be escalated to, and all of them need room under their cap.
+func (uc *UseCase) reviewersAvailable(ctx context.Context, userIDs []string, teams []*entity.TeamDeactivation) (bool, error) {
+	l := logger.FromCtx(ctx)
 
-	reviewersByPR := make(map[string]map[string]struct{}, len(reviewersMap))
-	for prID, reviewers := range reviewersMap {
-		set := make(map[string]struct{}, len(reviewers))
-		for _, reviewerID := range reviewers {
-			if reviewerID != "" {
-				set[reviewerID] = struct{}{}
+	planned := make(map[string]int)
+	reviewerIDs := make([]string, 0)
+	for _, team := range teams {
+		var candidates, leads map[string]struct{}
+		for _, move := range team.ReviewReassignments {
+			if move.Escalated {
+				if leads == nil {
+					leadIDs, err := uc.userRepo.GetLeadIDs(ctx, team.TeamName, userIDs)
+					if err != nil {
+						l.Warn("failed to get team leads", zap.Error(err))
+						return false, err
+					}
+					leads = idSet(leadIDs)
+				}
+				if _, ok := leads[move.NewReviewerID]; !ok {
+					return false, nil
+				}
+			} else {
+				if candidates == nil {
+					candidateIDs, _, err := uc.planner.CandidateIDs(ctx, team.TeamName, userIDs)
+					if err != nil {
+						return false, err
+					}
+					candidates = idSet(candidateIDs)
+				}
+				if _, ok := candidates[move.NewReviewerID]; !ok {
+					return false, nil
+				}
+			}
+			if _, seen := planned[move.NewReviewerID]; !seen {
+				reviewerIDs = append(reviewerIDs, move.NewReviewerID)
 			}
+			planned[move.NewReviewerID]++
 		}
-		reviewersByPR[prID] = set
 	}
-
-	currentIndex := 0
-	if len(candidateIDs) > 0 {
-		r := rand.New(rand.NewSource(time.Now().UnixNano()))
-		currentIndex = r.Intn(len(candidateIDs))
+	if len(reviewerIDs) == 0 {
+		return true, nil
 	}
 
-	for _, record := range reviewRecords {
-		if record == nil {
-			continue
+	capacities, err := uc.userRepo.GetReviewCapacities(ctx, reviewerIDs)
+	if err != nil {
+		l.Warn("failed to get review capacities", zap.Error(err))
+		return false, err
+	}
+	for _, id := range reviewerIDs {
+		if left, capped := capacities[id]; capped && left < planned[id] {
+			return false, nil
 		}
+	}
+	return true, nil
+}
 
-		prID := record.PullRequestID
-		oldReviewerID := record.ReviewerID
+func idSet(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
 
-		existingReviewers, ok := reviewersByPR[prID]
-		if !ok {
-			existingReviewers = make(map[string]struct{})
-			reviewersByPR[prID] = existingReviewers
+// sameIDs reports whether a and b hold the same IDs, given neither has
+// duplicates.
+func sameIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := make(map[string]struct{}, len(a))
+	for _, id := range a {
+		set[id] = struct{}{}
+	}
+	for _, id := range b {
+		if _, ok := set[id]; !ok {
+			return false
 		}
+	}
+	return true
+}
 
-		var replacementID string
-		for i := 0; i < len(candidateIDs); i++ {
-			idx := (currentIndex + i) % len(candidateIDs)
-			candidateID := candidateIDs[idx]
-			if candidateID == "" {
-				continue
-			}
-			if _, excluded := excludeSet[candidateID]; excluded {
-				continue
-			}
-			if _, alreadyAssigned := existingReviewers[candidateID]; alreadyAssigned {
-				continue
-			}
-			replacementID = candidateID
-			currentIndex = (idx + 1) % len(candidateIDs)
-			break
-		}
+func (uc *UseCase) validateTeamAndUsers(ctx context.Context, teamName string, userIDs []string) ([]*entity.User, error) {
+	ctx, span := tracing.Start(ctx, "user.validateTeamAndUsers")
+	defer span.End()
+	l := logger.FromCtx(ctx)
+	exists, err := uc.teamRepo.CheckTeamNameExists(ctx, teamName)
+	if err != nil {
+		l.Warn("failed to check team existence", zap.Error(err))
+		return nil, err
+	}
+	if !exists {
+		l.Warn("team does not exist", zap.String("teamName", teamName))
+		return nil, entity.ErrNotFound
+	}
 
-		if replacementID == "" {
-			unreassignedReviews = append(unreassignedReviews, &entity.UnreassignedReview{
-				PullRequestID: prID,
-				ReviewerID:    oldReviewerID,
-			})
-			continue
-		}
+	users, err := uc.userRepo.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		l.Warn("failed to get users by IDs", zap.Error(err))
+		return nil, err
+	}
 
-		reviewReassignments = append(reviewReassignments, &entity.ReviewReassignment{
-			PullRequestID: prID,
-			OldReviewerID: oldReviewerID,
-			NewReviewerID: replacementID,
-		})
+	if len(users) == 0 {
+		l.Warn("no users found for deactivation", zap.String("teamName", teamName))
+		return nil, entity.ErrNotFound
+	}
 
-		oldReviewsToRemove = append(oldReviewsToRemove, &entity.ReviewRecord{
-			PullRequestID: prID,
-			ReviewerID:    oldReviewerID,
-		})
-		newReviewsToAdd = append(newReviewsToAdd, &entity.ReviewRecord{
-			PullRequestID: prID,
-			ReviewerID:    replacementID,
-		})
+	memberIDs, err := uc.userRepo.GetTeamMemberIDs(ctx, teamName, userIDs)
+	if err != nil {
+		l.Warn("failed to get team member IDs", zap.Error(err))
+		return nil, err
+	}
 
-		existingReviewers[replacementID] = struct{}{}
+	// Users only need to share the team from the request, which may be a
+	// secondary team for some of them.
+	if len(memberIDs) != len(users) {
+		l.Warn("users are not members of the request team", zap.String("teamName", teamName))
+		return nil, entity.ErrUsersNotInSameTeam
 	}
 
-	return reviewReassignments, unreassignedReviews, oldReviewsToRemove, newReviewsToAdd, nil
+	return users, nil
 }
 
-func (uc *UseCase) applyMassDeactivation(
-	ctx context.Context,
-	userIDs []string,
-	oldReviewsToRemove []*entity.ReviewRecord,
-	newReviewsToAdd []*entity.ReviewRecord,
-) error {
+func (uc *UseCase) applyMassDeactivation(ctx context.Context, userIDs []string, plan *reassignment.Plan) error {
+	ctx, span := tracing.Start(ctx, "user.applyMassDeactivation")
+	defer span.End()
 	l := logger.FromCtx(ctx)
 	if err := uc.userRepo.DeactivateUsers(ctx, userIDs); err != nil {
 		l.Warn("failed to deactivate users", zap.Error(err))
 		return err
 	}
 
-	if len(oldReviewsToRemove) > 0 {
-		if err := uc.pullRequestRepo.RemoveReviewersBatch(ctx, oldReviewsToRemove); err != nil {
-			l.Warn("failed to remove old reviewers in batch", zap.Error(err))
-			return err
-		}
-	}
-
-	if len(newReviewsToAdd) > 0 {
-		if err := uc.pullRequestRepo.AddReviewersBatch(ctx, newReviewsToAdd); err != nil {
-			l.Warn("failed to add new reviewers in batch", zap.Error(err))
-			return err
-		}
+	if err := uc.planner.Apply(ctx, plan); err != nil {
+		return err
 	}
 
-	return nil
+	return uc.planner.Queue(ctx, plan)
 }
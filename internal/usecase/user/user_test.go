@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/entity"
 	"github.com/derletzte256/avito-assignment-2025-autumn/internal/usecase/mocks"
@@ -39,8 +40,13 @@ func newUseCaseWithMocks(t *testing.T) (*UseCase, *mocks.MockUserRepository, *mo
 	return uc, userRepo, prRepo, teamRepo, trManager
 }
 
+func activateRequest(userID string) *entity.SetUserActiveRequest {
+	active := true
+	return &entity.SetUserActiveRequest{UserID: userID, IsActive: &active}
+}
+
 func TestUseCase_SetIsActive_Success(t *testing.T) {
-	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+	uc, userRepo, prRepo, _, trManager := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	userID := "u1"
@@ -59,15 +65,287 @@ func TestUseCase_SetIsActive_Success(t *testing.T) {
 		SetIsActive(ctx, userID, true).
 		Return(nil)
 
+	prRepo.EXPECT().
+		CancelReviewerReplacements(ctx, userID).
+		Return(nil)
+
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{userID}).
+		Return([]*entity.BacklogCandidates{}, nil)
+
 	userRepo.EXPECT().
 		GetUserByID(ctx, userID).
 		Return(expectedUser, nil)
 
-	result, err := uc.SetIsActive(ctx, userID, true)
+	result, err := uc.SetIsActive(ctx, activateRequest(userID))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, expectedUser, result)
+	assert.Equal(t, expectedUser, result.User)
+	assert.Empty(t, result.ReviewReassignments)
+	assert.True(t, trManager.doCalled)
+}
+
+func TestUseCase_SetIsActive_BackfillsBacklog(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	userID := "u1"
+
+	userRepo.EXPECT().CheckUserExists(ctx, userID).Return(true, nil)
+	userRepo.EXPECT().SetIsActive(ctx, userID, true).Return(nil)
+	prRepo.EXPECT().CancelReviewerReplacements(ctx, userID).Return(nil)
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{userID}).
+		Return([]*entity.BacklogCandidates{
+			{PullRequestID: "pr-1", MissingReviewers: 1, DepartedReviewerIDs: []string{"u9"}, CandidateIDs: []string{userID}},
+			{PullRequestID: "pr-2", MissingReviewers: 2, CandidateIDs: []string{userID}},
+			{PullRequestID: "pr-3", MissingReviewers: 1, CandidateIDs: []string{userID}},
+		}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{userID}).
+		Return(map[string]int{userID: 2}, nil)
+
+	prRepo.EXPECT().DequeueAwaitingReviewer(ctx, "pr-1").Return("u9", true, nil)
+	prRepo.EXPECT().RemoveReviewer(ctx, "pr-1", "u9").Return(nil)
+	prRepo.EXPECT().AddNewReviewer(ctx, "pr-1", userID).Return(nil)
+	prRepo.EXPECT().DequeueAwaitingReviewer(ctx, "pr-2").Return("", true, nil)
+	prRepo.EXPECT().AddNewReviewer(ctx, "pr-2", userID).Return(nil)
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(&entity.User{ID: userID, IsActive: true}, nil)
+
+	_, err := uc.SetIsActive(ctx, activateRequest(userID))
+
+	assert.NoError(t, err)
+	prRepo.AssertNotCalled(t, "DequeueAwaitingReviewer", ctx, "pr-3")
+}
+
+func TestUseCase_SetIsActive_BackfillKeepsUnqueuedInactiveReviewer(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	userID := "u1"
+
+	userRepo.EXPECT().CheckUserExists(ctx, userID).Return(true, nil)
+	userRepo.EXPECT().SetIsActive(ctx, userID, true).Return(nil)
+	prRepo.EXPECT().CancelReviewerReplacements(ctx, userID).Return(nil)
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{userID}).
+		Return([]*entity.BacklogCandidates{
+			{PullRequestID: "pr-1", MissingReviewers: 1, DepartedReviewerIDs: []string{"u9"}, CandidateIDs: []string{userID}},
+		}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{userID}).
+		Return(map[string]int{}, nil)
+
+	prRepo.EXPECT().DequeueAwaitingReviewer(ctx, "pr-1").Return("", true, nil)
+	prRepo.EXPECT().AddNewReviewer(ctx, "pr-1", userID).Return(nil)
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(&entity.User{ID: userID, IsActive: true}, nil)
+
+	_, err := uc.SetIsActive(ctx, activateRequest(userID))
+
+	assert.NoError(t, err)
+	prRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_SetIsActive_BackfillCountsReturnedReviewerAsFilled(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	userID := "u1"
+
+	userRepo.EXPECT().CheckUserExists(ctx, userID).Return(true, nil)
+	userRepo.EXPECT().SetIsActive(ctx, userID, true).Return(nil)
+	prRepo.EXPECT().CancelReviewerReplacements(ctx, userID).Return(nil)
+
+	// u9 was queued to be replaced but is back, so only the plain slot is filled.
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{userID}).
+		Return([]*entity.BacklogCandidates{
+			{PullRequestID: "pr-1", MissingReviewers: 2, DepartedReviewerIDs: []string{}, CandidateIDs: []string{userID}},
+		}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{userID}).
+		Return(map[string]int{}, nil)
+
+	prRepo.EXPECT().DequeueAwaitingReviewer(ctx, "pr-1").Return("u9", true, nil).Once()
+	prRepo.EXPECT().DequeueAwaitingReviewer(ctx, "pr-1").Return("", true, nil).Once()
+	prRepo.EXPECT().AddNewReviewer(ctx, "pr-1", userID).Return(nil).Once()
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, userID).
+		Return(&entity.User{ID: userID, IsActive: true}, nil)
+
+	_, err := uc.SetIsActive(ctx, activateRequest(userID))
+
+	assert.NoError(t, err)
+	prRepo.AssertNotCalled(t, "RemoveReviewer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_SetIsActive_TakesOverReviewsFromMostLoaded(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := activateRequest("u1")
+	req.TargetOpenReviews = 2
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(true, nil)
+	userRepo.EXPECT().SetIsActive(ctx, "u1", true).Return(nil)
+	prRepo.EXPECT().CancelReviewerReplacements(ctx, "u1").Return(nil)
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{"u1"}).
+		Return([]*entity.BacklogCandidates{}, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: true, TeamName: "team-1"}, nil)
+
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-3", ReviewerID: "u2"},
+		{PullRequestID: "pr-3", ReviewerID: "u3"},
+		{PullRequestID: "pr-2", ReviewerID: "u3"},
+		{PullRequestID: "pr-1", ReviewerID: "u3"},
+	}
+	prRepo.EXPECT().GetTransferableReviews(ctx, "u1", "team-1").Return(reviews, nil)
+
+	// Only reviews on the team's PRs count: u2 has 2 of them, u3 has 4.
+	prRepo.EXPECT().
+		GetOpenPullRequestsByTeamName(ctx, "team-1").
+		Return([]*entity.PullRequest{{ID: "pr-1"}, {ID: "pr-2"}, {ID: "pr-3"}, {ID: "pr-4"}, {ID: "pr-5"}}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1", "pr-2", "pr-3", "pr-4", "pr-5"}).
+		Return(map[string][]string{
+			"pr-1": {"u3"},
+			"pr-2": {"u3"},
+			"pr-3": {"u2", "u3"},
+			"pr-4": {"u2"},
+			"pr-5": {"u3", "u4"},
+		}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"u1"}).
+		Return(map[string]int{}, nil)
+
+	// u3 gives pr-3 away first; afterwards u2 and u3 hold 2 and 3 reviews, so
+	// u3 also gives pr-2 and u1 reaches the target.
+	moved := []*entity.ReviewRecord{
+		{PullRequestID: "pr-3", ReviewerID: "u3"},
+		{PullRequestID: "pr-2", ReviewerID: "u3"},
+	}
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, moved).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{
+			{PullRequestID: "pr-3", ReviewerID: "u1"},
+			{PullRequestID: "pr-2", ReviewerID: "u1"},
+		}).
+		Return(nil)
+
+	resp, err := uc.SetIsActive(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-3", OldReviewerID: "u3", NewReviewerID: "u1"},
+		{PullRequestID: "pr-2", OldReviewerID: "u3", NewReviewerID: "u1"},
+	}, resp.ReviewReassignments)
+}
+
+func TestUseCase_SetIsActive_TakeOverIgnoresOtherTeamsReviews(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := activateRequest("u1")
+	req.TargetOpenReviews = 2
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(true, nil)
+	userRepo.EXPECT().SetIsActive(ctx, "u1", true).Return(nil)
+	prRepo.EXPECT().CancelReviewerReplacements(ctx, "u1").Return(nil)
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{"u1"}).
+		Return([]*entity.BacklogCandidates{}, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: true, TeamName: "team-1"}, nil)
+
+	// u2 is busy with other teams, but has a single review in this one.
+	prRepo.EXPECT().
+		GetTransferableReviews(ctx, "u1", "team-1").
+		Return([]*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}, nil)
+	prRepo.EXPECT().
+		GetOpenPullRequestsByTeamName(ctx, "team-1").
+		Return([]*entity.PullRequest{{ID: "pr-1"}}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u2"}}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"u1"}).
+		Return(map[string]int{}, nil)
+
+	resp, err := uc.SetIsActive(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.ReviewReassignments)
+	prRepo.AssertNotCalled(t, "RemoveReviewersBatch", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_SetIsActive_ReassignsOpenReviews(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	inactive := false
+	req := &entity.SetUserActiveRequest{UserID: "u1", IsActive: &inactive, ReassignOpenReviews: true}
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u1"},
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(true, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: true, TeamName: "team-1"}, nil).
+		Once()
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, "team-1", []string{"u1"}).
+		Return([]string{"u2"}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, []string{"u1"}).Return(reviews, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string][]string{
+			"pr-1": {"u1"},
+			"pr-2": {"u1", "u2"},
+		}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string]string{"pr-1": "u9", "pr-2": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"u2"}).
+		Return(map[string]int{}, nil)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, "team-1").Return(false, nil)
+
+	userRepo.EXPECT().DeactivateUsers(ctx, []string{"u1"}).Return(nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, []*entity.ReviewRecord{reviews[0]}).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+	prRepo.EXPECT().EnqueueReviewerReplacements(ctx, "pr-2", []string{"u1"}).Return(nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: false, TeamName: "team-1"}, nil)
+
+	resp, err := uc.SetIsActive(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.False(t, resp.User.IsActive)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, resp.ReviewReassignments)
+	assert.Equal(t, []*entity.UnreassignedReview{
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}, resp.UnreassignedReviews)
+	userRepo.AssertNotCalled(t, "SetIsActive", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestUseCase_SetIsActive_UserNotFound(t *testing.T) {
@@ -80,7 +358,7 @@ func TestUseCase_SetIsActive_UserNotFound(t *testing.T) {
 		CheckUserExists(ctx, userID).
 		Return(false, nil)
 
-	result, err := uc.SetIsActive(ctx, userID, true)
+	result, err := uc.SetIsActive(ctx, activateRequest(userID))
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -101,7 +379,7 @@ func TestUseCase_SetIsActive_CheckUserExistsError(t *testing.T) {
 		CheckUserExists(ctx, userID).
 		Return(false, expectedErr)
 
-	result, err := uc.SetIsActive(ctx, userID, true)
+	result, err := uc.SetIsActive(ctx, activateRequest(userID))
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -288,6 +566,10 @@ func TestUseCase_MassDeactivateUsers_Success_NoReviews(t *testing.T) {
 			{ID: "u1", TeamName: req.TeamName},
 		}, nil)
 
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return(req.UserIDs, nil)
+
 	userRepo.EXPECT().
 		GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).
 		Return([]string{"u2"}, nil)
@@ -313,26 +595,1255 @@ func TestUseCase_MassDeactivateUsers_Success_NoReviews(t *testing.T) {
 	prRepo.AssertNotCalled(t, "AddReviewersBatch", mock.Anything, mock.Anything)
 }
 
-func TestUseCase_MassDeactivateUsers_TeamNotFound(t *testing.T) {
-	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+func TestUseCase_MassDeactivateUsers_EscalatesToLead(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
 
 	ctx := context.Background()
 	req := &entity.MassDeactivateUsersRequest{
-		TeamName: "unknown-team",
+		TeamName: "team-1",
 		UserIDs:  []string{"u1"},
 	}
+	review := &entity.ReviewRecord{PullRequestID: "pr-1", ReviewerID: "u1"}
 
 	teamRepo.EXPECT().
 		CheckTeamNameExists(ctx, req.TeamName).
-		Return(false, nil)
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return(req.UserIDs, nil)
+
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).
+		Return([]string{}, nil)
+
+	prRepo.EXPECT().
+		GetReviewsByReviewerIDs(ctx, req.UserIDs).
+		Return([]*entity.ReviewRecord{review}, nil)
+
+	teamRepo.EXPECT().
+		IsLeadEscalationEnabled(ctx, req.TeamName).
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		GetLeadIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"lead-1"}, nil)
+
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"lead-1"}).
+		Return(map[string]int{}, nil)
+
+	userRepo.EXPECT().
+		DeactivateUsers(ctx, req.UserIDs).
+		Return(nil)
+
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{review}).
+		Return(nil)
+
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "lead-1"}}).
+		Return(nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "lead-1", Escalated: true},
+	}, result.ReviewReassignments)
+	assert.Empty(t, result.UnreassignedReviews)
+}
+
+func TestUseCase_MassDeactivateUsers_LeadAtCap(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u1"},
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+	userRepo.EXPECT().GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).Return(req.UserIDs, nil)
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).Return([]string{}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, req.UserIDs).Return(reviews, nil)
+
+	// The lead has room for one more review only.
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, req.TeamName).Return(true, nil)
+	userRepo.EXPECT().GetLeadIDs(ctx, req.TeamName, req.UserIDs).Return([]string{"lead-1"}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1", "pr-2"}).
+		Return(map[string][]string{"pr-1": {"u1"}, "pr-2": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1", "pr-2"}).
+		Return(map[string]string{"pr-1": "u9", "pr-2": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"lead-1"}).
+		Return(map[string]int{"lead-1": 1}, nil)
+
+	userRepo.EXPECT().DeactivateUsers(ctx, req.UserIDs).Return(nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, []*entity.ReviewRecord{reviews[0]}).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "lead-1"}}).
+		Return(nil)
+	prRepo.EXPECT().EnqueueReviewerReplacements(ctx, "pr-2", []string{"u1"}).Return(nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "lead-1", Escalated: true},
+	}, result.ReviewReassignments)
+	assert.Equal(t, []*entity.UnreassignedReview{{PullRequestID: "pr-2", ReviewerID: "u1"}}, result.UnreassignedReviews)
+}
+
+func TestUseCase_MassDeactivateUsers_LeadIsAuthor(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+	}
+	review := &entity.ReviewRecord{PullRequestID: "pr-1", ReviewerID: "u1"}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return(req.UserIDs, nil)
+
+	// The only other member is the lead, who authored the PR.
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"lead-1"}, nil)
+
+	prRepo.EXPECT().
+		GetReviewsByReviewerIDs(ctx, req.UserIDs).
+		Return([]*entity.ReviewRecord{review}, nil)
+
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "lead-1"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"lead-1"}).
+		Return(map[string]int{}, nil)
+
+	teamRepo.EXPECT().
+		IsLeadEscalationEnabled(ctx, req.TeamName).
+		Return(true, nil)
+
+	userRepo.EXPECT().
+		GetLeadIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"lead-1"}, nil)
+
+	userRepo.EXPECT().
+		DeactivateUsers(ctx, req.UserIDs).
+		Return(nil)
+
+	prRepo.EXPECT().
+		EnqueueReviewerReplacements(ctx, "pr-1", []string{"u1"}).
+		Return(nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.ReviewReassignments)
+	assert.Equal(t, []*entity.UnreassignedReview{{PullRequestID: "pr-1", ReviewerID: "u1"}}, result.UnreassignedReviews)
+
+	prRepo.AssertNotCalled(t, "AddReviewersBatch", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_MassDeactivateUsers_NotTeamMember(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1", "u2"},
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+
+	// u2 belongs to team-1 only as a secondary member, u1 not at all.
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{
+			{ID: "u1", TeamName: "team-2"},
+			{ID: "u2", TeamName: "team-2"},
+		}, nil)
+
+	userRepo.EXPECT().
+		GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"u2"}, nil)
 
 	result, err := uc.MassDeactivateUsers(ctx, req)
 
 	assert.Nil(t, result)
-	assert.Error(t, err)
-	assert.True(t, errors.Is(err, entity.ErrNotFound))
-	assert.True(t, trManager.doCalled)
+	assert.True(t, errors.Is(err, entity.ErrUsersNotInSameTeam))
 
-	userRepo.AssertNotCalled(t, "GetUsersByIDs", mock.Anything, mock.Anything)
 	prRepo.AssertNotCalled(t, "GetReviewsByReviewerIDs", mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_MassDeactivateUsers_TeamNotFound(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName: "unknown-team",
+		UserIDs:  []string{"u1"},
+	}
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(false, nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
+	assert.True(t, trManager.doCalled)
+
+	userRepo.AssertNotCalled(t, "GetUsersByIDs", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "GetReviewsByReviewerIDs", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_MassDeactivateUsers_DryRunSavesPlan(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName: "team-1",
+		UserIDs:  []string{"u1"},
+		DryRun:   true,
+	}
+	review := &entity.ReviewRecord{PullRequestID: "pr-1", ReviewerID: "u1"}
+
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+	userRepo.EXPECT().GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).Return(req.UserIDs, nil)
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, req.TeamName, req.UserIDs).
+		Return([]string{"u2"}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, req.UserIDs).Return([]*entity.ReviewRecord{review}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().GetReviewCapacities(ctx, []string{"u2"}).Return(map[string]int{}, nil)
+	prRepo.EXPECT().GetReviewsFingerprint(ctx, req.UserIDs).Return("fp-1", nil)
+
+	var saved *entity.DeactivationPlan
+	userRepo.EXPECT().
+		SaveDeactivationPlan(ctx, mock.Anything).
+		RunAndReturn(func(_ context.Context, plan *entity.DeactivationPlan) error {
+			saved = plan
+			return nil
+		})
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.PlanToken, 32)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, result.ReviewReassignments)
+
+	assert.Equal(t, result.PlanToken, saved.Token)
+	assert.Equal(t, "fp-1", saved.Fingerprint)
+	assert.Equal(t, result.ReviewReassignments, saved.Reassignments)
+	assert.Equal(t, *result.PlanExpiresAt, saved.ExpiresAt)
+
+	userRepo.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "RemoveReviewersBatch", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "AddReviewersBatch", mock.Anything, mock.Anything)
+}
+
+// expectPreviewedPlan sets up validation of u1 in team-1 and a stored plan
+// that moves u1's review of pr-1 to u2.
+func expectPreviewedPlan(ctx context.Context, req *entity.MassDeactivateUsersRequest, userRepo *mocks.MockUserRepository, teamRepo *mocks.MockTeamRepository) {
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{{ID: "u1", TeamName: req.TeamName}}, nil)
+	userRepo.EXPECT().GetTeamMemberIDs(ctx, req.TeamName, req.UserIDs).Return(req.UserIDs, nil)
+	userRepo.EXPECT().
+		TakeDeactivationPlan(ctx, req.PlanToken).
+		Return(&entity.DeactivationPlan{
+			Token:       req.PlanToken,
+			TeamName:    "team-1",
+			UserIDs:     []string{"u1"},
+			Fingerprint: "fp-1",
+			Reassignments: []*entity.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+			},
+			Unreassigned: []*entity.UnreassignedReview{},
+		}, nil)
+}
+
+func TestUseCase_MassDeactivateUsers_AppliesPreviewedPlan(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName:  "team-1",
+		UserIDs:   []string{"u1"},
+		PlanToken: "0123456789abcdef0123456789abcdef",
+	}
+	expectPreviewedPlan(ctx, req, userRepo, teamRepo)
+
+	prRepo.EXPECT().GetReviewsFingerprint(ctx, req.UserIDs).Return("fp-1", nil)
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, "team-1", req.UserIDs).
+		Return([]string{"u2", "u3"}, nil)
+	userRepo.EXPECT().GetReviewCapacities(ctx, []string{"u2"}).Return(map[string]int{"u2": 1}, nil)
+	userRepo.EXPECT().DeactivateUsers(ctx, req.UserIDs).Return(nil)
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u1"}}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Empty(t, result.PlanToken)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, result.ReviewReassignments)
+
+	prRepo.AssertNotCalled(t, "GetReviewsByReviewerIDs", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_MassDeactivateUsers_PreviewedPlanOutdated(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{
+		TeamName:  "team-1",
+		UserIDs:   []string{"u1"},
+		PlanToken: "0123456789abcdef0123456789abcdef",
+	}
+	expectPreviewedPlan(ctx, req, userRepo, teamRepo)
+
+	prRepo.EXPECT().GetReviewsFingerprint(ctx, req.UserIDs).Return("fp-2", nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrPlanOutdated))
+
+	userRepo.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+	prRepo.AssertNotCalled(t, "RemoveReviewersBatch", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_MassDeactivateUsers_PreviewedReviewerUnavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(ctx context.Context, req *entity.MassDeactivateUsersRequest, userRepo *mocks.MockUserRepository)
+	}{
+		{
+			name: "no longer an active team member",
+			expect: func(ctx context.Context, req *entity.MassDeactivateUsersRequest, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().
+					GetActiveUsersIDsByTeamName(ctx, "team-1", req.UserIDs).
+					Return([]string{"u3"}, nil)
+			},
+		},
+		{
+			name: "at the cap",
+			expect: func(ctx context.Context, req *entity.MassDeactivateUsersRequest, userRepo *mocks.MockUserRepository) {
+				userRepo.EXPECT().
+					GetActiveUsersIDsByTeamName(ctx, "team-1", req.UserIDs).
+					Return([]string{"u2", "u3"}, nil)
+				userRepo.EXPECT().GetReviewCapacities(ctx, []string{"u2"}).Return(map[string]int{"u2": 0}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+			ctx := context.Background()
+			req := &entity.MassDeactivateUsersRequest{
+				TeamName:  "team-1",
+				UserIDs:   []string{"u1"},
+				PlanToken: "0123456789abcdef0123456789abcdef",
+			}
+			expectPreviewedPlan(ctx, req, userRepo, teamRepo)
+			prRepo.EXPECT().GetReviewsFingerprint(ctx, req.UserIDs).Return("fp-1", nil)
+			tt.expect(ctx, req, userRepo)
+
+			result, err := uc.MassDeactivateUsers(ctx, req)
+
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, entity.ErrPlanOutdated))
+
+			userRepo.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+			prRepo.AssertNotCalled(t, "RemoveReviewersBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUseCase_MassDeactivateUsers_AcrossTeams(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{UserIDs: []string{"u1", "u2"}}
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{
+			{ID: "u2", TeamName: "team-b"},
+			{ID: "u1", TeamName: "team-a"},
+		}, nil)
+
+	// c1 is a member of both teams, and u1 and u2 both review pr-1.
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, "team-a", req.UserIDs).
+		Return([]string{"c1"}, nil)
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, "team-b", req.UserIDs).
+		Return([]string{"c1", "c2"}, nil)
+	prRepo.EXPECT().
+		GetReviewsByReviewerIDs(ctx, []string{"u1"}).
+		Return([]*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u1"}}, nil)
+	prRepo.EXPECT().
+		GetReviewsByReviewerIDs(ctx, []string{"u2"}).
+		Return([]*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1", "u2"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().GetReviewCapacities(ctx, mock.Anything).Return(map[string]int{}, nil)
+
+	userRepo.EXPECT().DeactivateUsers(ctx, req.UserIDs).Return(nil)
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{
+			{PullRequestID: "pr-1", ReviewerID: "u1"},
+			{PullRequestID: "pr-1", ReviewerID: "u2"},
+		}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{
+			{PullRequestID: "pr-1", ReviewerID: "c1"},
+			{PullRequestID: "pr-1", ReviewerID: "c2"},
+		}).
+		Return(nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.TeamName)
+	assert.Len(t, result.ReviewReassignments, 2)
+	assert.Equal(t, []*entity.TeamDeactivation{
+		{
+			TeamName: "team-a",
+			UserIDs:  []string{"u1"},
+			ReviewReassignments: []*entity.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "c1"},
+			},
+			UnreassignedReviews: []*entity.UnreassignedReview{},
+		},
+		{
+			TeamName: "team-b",
+			UserIDs:  []string{"u2"},
+			ReviewReassignments: []*entity.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "c2"},
+			},
+			UnreassignedReviews: []*entity.UnreassignedReview{},
+		},
+	}, result.Teams)
+}
+
+func TestUseCase_MassDeactivateUsers_AcrossTeamsNoPrimaryTeam(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.MassDeactivateUsersRequest{UserIDs: []string{"u1", "u2"}}
+
+	userRepo.EXPECT().
+		GetUsersByIDs(ctx, req.UserIDs).
+		Return([]*entity.User{
+			{ID: "u1", TeamName: "team-a"},
+			{ID: "u2"},
+		}, nil)
+
+	result, err := uc.MassDeactivateUsers(ctx, req)
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrNoPrimaryTeam))
+
+	prRepo.AssertNotCalled(t, "GetReviewsByReviewerIDs", mock.Anything, mock.Anything)
+	userRepo.AssertNotCalled(t, "DeactivateUsers", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_HandoverReviews_MovesAndSkips(t *testing.T) {
+	uc, userRepo, prRepo, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.HandoverReviewsRequest{FromUserID: "u1", ToUserID: "u2"}
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&entity.User{ID: "u2", TeamName: "team-1", IsActive: true}, nil)
+	userRepo.EXPECT().IsUnavailable(ctx, "u2").Return(false, nil)
+	prRepo.EXPECT().
+		GetPullRequestsByReviewerID(ctx, "u1").
+		Return([]*entity.PullRequest{
+			{ID: "pr-5", AuthorID: "u3", Status: entity.StatusOpen},
+			{ID: "pr-4", AuthorID: "u3", Status: entity.StatusOpen},
+			{ID: "pr-3", AuthorID: "u2", Status: entity.StatusOpen},
+			{ID: "pr-2", AuthorID: "u3", Status: entity.StatusOpen},
+			{ID: "pr-1", AuthorID: "u3", Status: entity.StatusMerged},
+		}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-5", "pr-4", "pr-3", "pr-2"}).
+		Return(map[string][]string{
+			"pr-5": {"u1"},
+			"pr-4": {"u1"},
+			"pr-3": {"u1"},
+			"pr-2": {"u1", "u2"},
+		}, nil)
+
+	// u2 has room for one more review, so pr-4 stays with u1.
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"u2"}).
+		Return(map[string]int{"u2": 1}, nil)
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-5", ReviewerID: "u1"}}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-5", ReviewerID: "u2"}}).
+		Return(nil)
+	prRepo.EXPECT().
+		AddAuditEvents(ctx, entity.AuditEventHandover, "team-1", []*entity.ReviewReassignment{
+			{PullRequestID: "pr-5", OldReviewerID: "u1", NewReviewerID: "u2"},
+		}).
+		Return(nil)
+
+	resp, err := uc.HandoverReviews(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, entity.HandoverScopeAll, resp.Scope)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-5", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, resp.Moved)
+	assert.Equal(t, []*entity.SkippedReview{
+		{PullRequestID: "pr-4", Reason: entity.SkipReasonCap},
+		{PullRequestID: "pr-3", Reason: entity.SkipReasonAuthor},
+		{PullRequestID: "pr-2", Reason: entity.SkipReasonReviewer},
+	}, resp.Skipped)
+}
+
+func TestUseCase_HandoverReviews_InactiveTarget(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.HandoverReviewsRequest{FromUserID: "u1", ToUserID: "u2"}
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&entity.User{ID: "u2", TeamName: "team-1"}, nil)
+
+	resp, err := uc.HandoverReviews(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrInactiveTarget))
+
+	prRepo.AssertNotCalled(t, "GetPullRequestsByReviewerID", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_HandoverReviews_DeletedTarget(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.HandoverReviewsRequest{FromUserID: "u1", ToUserID: "deleted-1"}
+	deletedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.ToUserID).
+		Return(&entity.User{ID: req.ToUserID, DeletedAt: &deletedAt}, nil)
+
+	resp, err := uc.HandoverReviews(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrUserNotFound))
+
+	prRepo.AssertNotCalled(t, "GetPullRequestsByReviewerID", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_HandoverReviews_UnavailableTarget(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.HandoverReviewsRequest{FromUserID: "u1", ToUserID: "u2"}
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+	userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&entity.User{ID: "u2", TeamName: "team-1", IsActive: true}, nil)
+	userRepo.EXPECT().IsUnavailable(ctx, "u2").Return(true, nil)
+
+	resp, err := uc.HandoverReviews(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrUnavailableTarget))
+
+	prRepo.AssertNotCalled(t, "GetPullRequestsByReviewerID", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ChangeTeam_UserNotFound(t *testing.T) {
+	uc, userRepo, _, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ChangeUserTeamRequest{UserID: "u1", TeamName: "team-2"}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.UserID).
+		Return(nil, entity.ErrNotFound)
+
+	resp, err := uc.ChangeTeam(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrUserNotFound))
+	assert.True(t, trManager.doCalled)
+
+	teamRepo.AssertNotCalled(t, "CheckTeamNameExists", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ChangeTeam_TeamNotFound(t *testing.T) {
+	uc, userRepo, _, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ChangeUserTeamRequest{UserID: "u1", TeamName: "unknown-team"}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.UserID).
+		Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(false, nil)
+
+	resp, err := uc.ChangeTeam(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrTeamNotFound))
+
+	userRepo.AssertNotCalled(t, "UpdateMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ChangeTeam_HandsOverReviews(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ChangeUserTeamRequest{UserID: "u1", TeamName: "team-2"}
+	userIDs := []string{"u1"}
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u1"},
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.UserID).
+		Return(&entity.User{ID: "u1", Username: "user1", IsActive: true, TeamName: "team-1"}, nil)
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+	teamRepo.EXPECT().
+		IsArchived(ctx, req.TeamName).
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		GetActiveUsersIDsByTeamName(ctx, "team-1", userIDs).
+		Return([]string{"u2"}, nil)
+
+	prRepo.EXPECT().
+		GetTeamReviewsByReviewerIDs(ctx, "team-1", userIDs).
+		Return(reviews, nil)
+
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string][]string{
+			"pr-1": {"u1"},
+			"pr-2": {"u1", "u2"},
+		}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string]string{"pr-1": "u9", "pr-2": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, mock.Anything).
+		Return(map[string]int{}, nil)
+
+	teamRepo.EXPECT().
+		IsLeadEscalationEnabled(ctx, "team-1").
+		Return(false, nil)
+
+	userRepo.EXPECT().
+		UpdateMembers(ctx, []*entity.Member{{ID: "u1", Username: "user1", IsActive: true}}, req.TeamName).
+		Return(nil)
+
+	prRepo.EXPECT().
+		RemoveReviewersBatch(ctx, []*entity.ReviewRecord{reviews[0]}).
+		Return(nil)
+
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+
+	prRepo.EXPECT().
+		EnqueueReviewerReplacements(ctx, "pr-2", []string{"u1"}).
+		Return(nil)
+
+	prRepo.EXPECT().
+		GetBacklogCandidates(ctx, []string{"u1"}).
+		Return([]*entity.BacklogCandidates{}, nil)
+
+	resp, err := uc.ChangeTeam(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, "team-1", resp.OldTeamName)
+	assert.Equal(t, req.TeamName, resp.User.TeamName)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+	}, resp.ReviewReassignments)
+	assert.Equal(t, []*entity.UnreassignedReview{
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}, resp.UnreassignedReviews)
+}
+
+func TestUseCase_ChangeTeam_TeamArchived(t *testing.T) {
+	uc, userRepo, _, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ChangeUserTeamRequest{UserID: "u1", TeamName: "team-2"}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.UserID).
+		Return(&entity.User{ID: "u1", TeamName: "team-1"}, nil)
+
+	teamRepo.EXPECT().
+		CheckTeamNameExists(ctx, req.TeamName).
+		Return(true, nil)
+	teamRepo.EXPECT().
+		IsArchived(ctx, req.TeamName).
+		Return(true, nil)
+
+	resp, err := uc.ChangeTeam(ctx, req)
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrTeamArchived))
+
+	userRepo.AssertNotCalled(t, "UpdateMembers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ChangeTeam_EscalatesToLead(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.ChangeUserTeamRequest{UserID: "u1", TeamName: "team-2"}
+	userIDs := []string{"u1"}
+	review := &entity.ReviewRecord{PullRequestID: "pr-1", ReviewerID: "u1"}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, req.UserID).
+		Return(&entity.User{ID: "u1", Username: "user1", IsActive: true, TeamName: "team-1"}, nil)
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, req.TeamName).Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, req.TeamName).Return(false, nil)
+
+	// Nobody is left in the old team, so the review goes to its lead.
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, "team-1", userIDs).Return([]string{}, nil)
+	prRepo.EXPECT().GetTeamReviewsByReviewerIDs(ctx, "team-1", userIDs).Return([]*entity.ReviewRecord{review}, nil)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, "team-1").Return(true, nil)
+	userRepo.EXPECT().GetLeadIDs(ctx, "team-1", userIDs).Return([]string{"lead-1"}, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, []string{"lead-1"}).
+		Return(map[string]int{}, nil)
+
+	userRepo.EXPECT().
+		UpdateMembers(ctx, []*entity.Member{{ID: "u1", Username: "user1", IsActive: true}}, req.TeamName).
+		Return(nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, []*entity.ReviewRecord{review}).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "lead-1"}}).
+		Return(nil)
+	prRepo.EXPECT().GetBacklogCandidates(ctx, userIDs).Return([]*entity.BacklogCandidates{}, nil)
+
+	resp, err := uc.ChangeTeam(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ReviewReassignment{
+		{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "lead-1", Escalated: true},
+	}, resp.ReviewReassignments)
+	assert.Empty(t, resp.UnreassignedReviews)
+
+	prRepo.AssertNotCalled(t, "EnqueueReviewerReplacements", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_CreateUser_WithTeam(t *testing.T) {
+	uc, userRepo, _, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreateUserRequest{UserID: "u1", Username: "user1", TeamName: "backend"}
+	expected := &entity.User{ID: "u1", Username: "user1", IsActive: true, TeamName: "backend"}
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(false, nil)
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "backend").Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, "backend").Return(false, nil)
+	userRepo.EXPECT().Create(ctx, expected).Return(nil)
+
+	user, err := uc.CreateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, expected, user)
+}
+
+func TestUseCase_CreateUser_AlreadyExists(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	isActive := false
+	req := &entity.CreateUserRequest{UserID: "u1", Username: "user1", IsActive: &isActive}
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(true, nil)
+
+	user, err := uc.CreateUser(ctx, req)
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, entity.ErrUserExists))
+	userRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_CreateUser_TeamArchived(t *testing.T) {
+	uc, userRepo, _, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.CreateUserRequest{UserID: "u1", Username: "user1", TeamName: "legacy"}
+
+	userRepo.EXPECT().CheckUserExists(ctx, "u1").Return(false, nil)
+	teamRepo.EXPECT().CheckTeamNameExists(ctx, "legacy").Return(true, nil)
+	teamRepo.EXPECT().IsArchived(ctx, "legacy").Return(true, nil)
+
+	user, err := uc.CreateUser(ctx, req)
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, entity.ErrTeamArchived))
+}
+
+func TestUseCase_UpdateUser_Success(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	username := "renamed"
+	req := &entity.UpdateUserRequest{UserID: "u1", Username: &username}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", Username: "user1", IsActive: true, TeamName: "backend"}, nil)
+	userRepo.EXPECT().
+		UpdateProfile(ctx, &entity.User{ID: "u1", Username: "renamed", IsActive: true, TeamName: "backend"}).
+		Return(nil)
+
+	user, err := uc.UpdateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed", user.Username)
+}
+
+func TestUseCase_UpdateUser_NotFound(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(nil, entity.ErrNotFound)
+
+	user, err := uc.UpdateUser(ctx, &entity.UpdateUserRequest{UserID: "u1"})
+
+	assert.Nil(t, user)
+	assert.True(t, errors.Is(err, entity.ErrUserNotFound))
+}
+
+func TestUseCase_SearchUsers_HasMore(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	users := []*entity.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}
+
+	userRepo.EXPECT().Search(ctx, "u", 3, 4).Return(users, nil)
+
+	resp, err := uc.SearchUsers(ctx, &entity.SearchUsersRequest{Query: "u", Limit: 2, Offset: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, users[:2], resp.Users)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, 2, resp.Limit)
+	assert.Equal(t, 4, resp.Offset)
+}
+
+func TestUseCase_UpdateUser_Profile(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	email, timezone := "", "Asia/Yekaterinburg"
+	req := &entity.UpdateUserRequest{UserID: "u1", Email: &email, Timezone: &timezone}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{
+			ID:        "u1",
+			Username:  "user1",
+			Email:     "user1@example.com",
+			Timezone:  "Europe/Moscow",
+			Seniority: entity.SenioritySenior,
+		}, nil)
+	userRepo.EXPECT().
+		UpdateProfile(ctx, &entity.User{
+			ID:        "u1",
+			Username:  "user1",
+			Timezone:  "Asia/Yekaterinburg",
+			Seniority: entity.SenioritySenior,
+		}).
+		Return(nil)
+
+	user, err := uc.UpdateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Empty(t, user.Email)
+	assert.Equal(t, "Asia/Yekaterinburg", user.Timezone)
+	assert.Equal(t, entity.SenioritySenior, user.Seniority)
+}
+
+func TestUseCase_DeleteUser_HandsOverReviews(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	req := &entity.DeleteUserRequest{UserID: "u1", Erase: true}
+	userIDs := []string{"u1"}
+	reviews := []*entity.ReviewRecord{
+		{PullRequestID: "pr-1", ReviewerID: "u1"},
+		{PullRequestID: "pr-2", ReviewerID: "u1"},
+	}
+	deletedAt := time.Date(2025, 12, 2, 10, 0, 0, 0, time.UTC)
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", Username: "user1", IsActive: true, TeamName: "team-1"}, nil)
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, "team-1", userIDs).Return([]string{"u2"}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, userIDs).Return(reviews, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string][]string{
+			"pr-1": {"u1"},
+			"pr-2": {"u1", "u2"},
+		}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, mock.Anything).
+		Return(map[string]string{"pr-1": "u9", "pr-2": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, mock.Anything).
+		Return(map[string]int{}, nil)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, "team-1").Return(false, nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, []*entity.ReviewRecord{reviews[0]}).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+	prRepo.EXPECT().EnqueueReviewerReplacements(ctx, "pr-2", []string{"u1"}).Return(nil)
+	userRepo.EXPECT().Delete(ctx, "u1", true).Return("deleted-abc", deletedAt, nil)
+
+	resp, err := uc.DeleteUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, &entity.DeleteUserResponse{
+		UserID:      "u1",
+		PseudonymID: "deleted-abc",
+		Erased:      true,
+		DeletedAt:   deletedAt,
+		ReviewReassignments: []*entity.ReviewReassignment{
+			{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2"},
+		},
+		UnreassignedReviews: []*entity.UnreassignedReview{
+			{PullRequestID: "pr-2", ReviewerID: "deleted-abc"},
+		},
+	}, resp)
+}
+
+func TestUseCase_DeleteUser_AlreadyDeleted(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	deletedAt := time.Now()
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "deleted-abc").
+		Return(&entity.User{ID: "deleted-abc", DeletedAt: &deletedAt}, nil)
+
+	resp, err := uc.DeleteUser(ctx, &entity.DeleteUserRequest{UserID: "deleted-abc"})
+
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, entity.ErrUserNotFound))
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUseCase_AddUnavailability_Success(t *testing.T) {
+	uc, userRepo, _, _, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	startsAt := time.Date(2025, 12, 10, 0, 0, 0, 0, time.UTC)
+	req := &entity.AddUnavailabilityRequest{
+		UserID:   "u1",
+		StartsAt: startsAt,
+		EndsAt:   startsAt.Add(48 * time.Hour),
+		Reason:   "vacation",
+	}
+
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(&entity.User{ID: "u1", IsActive: true}, nil)
+	userRepo.EXPECT().
+		AddUnavailability(ctx, mock.AnythingOfType("*entity.Unavailability")).
+		Run(func(_ context.Context, u *entity.Unavailability) { u.ID = 7 }).
+		Return(nil)
+
+	result, err := uc.AddUnavailability(ctx, req)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, &entity.Unavailability{
+		ID:       7,
+		UserID:   "u1",
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   "vacation",
+	}, result)
+}
+
+func TestUseCase_AddUnavailability_UserNotFound(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+
+	userRepo.EXPECT().GetUserByID(ctx, "u404").Return(nil, entity.ErrNotFound)
+
+	result, err := uc.AddUnavailability(ctx, &entity.AddUnavailabilityRequest{UserID: "u404"})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrUserNotFound))
+}
+
+func TestUseCase_RemoveUnavailability_NotFound(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+
+	userRepo.EXPECT().RemoveUnavailability(ctx, "u1", int64(3)).Return(entity.ErrNotFound)
+
+	result, err := uc.RemoveUnavailability(ctx, &entity.RemoveUnavailabilityRequest{UserID: "u1", ID: 3})
+
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, entity.ErrAbsenceNotFound))
+}
+
+func TestUseCase_ReassignReviewsOfAbsentUsers(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, trManager := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	userIDs := []string{"u1"}
+	reviews := []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u1"}}
+
+	// Two overlapping absences of the same user hand reviews over once.
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(&entity.Unavailability{ID: 1, UserID: "u1"}, nil).
+		Once()
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(&entity.Unavailability{ID: 2, UserID: "u1"}, nil).
+		Once()
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(nil, entity.ErrNotFound).
+		Once()
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: true, TeamName: "team-1"}, nil).
+		Once()
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, "team-1", userIDs).Return([]string{"u2"}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, userIDs).Return(reviews, nil)
+	prRepo.EXPECT().
+		GetReviewersByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string][]string{"pr-1": {"u1"}}, nil)
+	prRepo.EXPECT().
+		GetAuthorsByPullRequestIDs(ctx, []string{"pr-1"}).
+		Return(map[string]string{"pr-1": "u9"}, nil)
+	userRepo.EXPECT().
+		GetReviewCapacities(ctx, mock.Anything).
+		Return(map[string]int{}, nil)
+	prRepo.EXPECT().RemoveReviewersBatch(ctx, reviews).Return(nil)
+	prRepo.EXPECT().
+		AddReviewersBatch(ctx, []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u2"}}).
+		Return(nil)
+	userRepo.EXPECT().MarkAbsencesHandled(ctx, []int64{1}).Return(nil)
+	userRepo.EXPECT().MarkAbsencesHandled(ctx, []int64{2}).Return(nil)
+
+	handled, err := uc.ReassignReviewsOfAbsentUsers(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.True(t, trManager.doCalled)
+	assert.Equal(t, 2, handled)
+	teamRepo.AssertNotCalled(t, "IsLeadEscalationEnabled", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ReassignReviewsOfAbsentUsers_NobodyAvailable(t *testing.T) {
+	uc, userRepo, prRepo, teamRepo, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	userIDs := []string{"u1"}
+	reviews := []*entity.ReviewRecord{{PullRequestID: "pr-1", ReviewerID: "u1"}}
+
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(&entity.Unavailability{ID: 1, UserID: "u1"}, nil).
+		Once()
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(nil, entity.ErrNotFound).
+		Once()
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", IsActive: true, TeamName: "team-1"}, nil)
+
+	// Nobody in the team can take the review, so the PR is queued.
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, "team-1", userIDs).Return([]string{}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, userIDs).Return(reviews, nil)
+	teamRepo.EXPECT().IsLeadEscalationEnabled(ctx, "team-1").Return(false, nil)
+	prRepo.EXPECT().EnqueueReviewerReplacements(ctx, "pr-1", userIDs).Return(nil)
+	userRepo.EXPECT().MarkAbsencesHandled(ctx, []int64{1}).Return(nil)
+
+	handled, err := uc.ReassignReviewsOfAbsentUsers(ctx, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, handled)
+	prRepo.AssertNotCalled(t, "AddReviewersBatch", mock.Anything, mock.Anything)
+}
+
+func TestUseCase_ReassignReviewsOfAbsentUsers_FailedAbsenceSkipped(t *testing.T) {
+	uc, userRepo, prRepo, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	dbErr := errors.New("db error")
+
+	// The handover of the first absence fails, the next claim skips it.
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{}).
+		Return(&entity.Unavailability{ID: 1, UserID: "u1"}, nil).
+		Once()
+	userRepo.EXPECT().GetUserByID(ctx, "u1").Return(nil, dbErr)
+
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{1}).
+		Return(&entity.Unavailability{ID: 2, UserID: "u2"}, nil).
+		Once()
+	userRepo.EXPECT().GetUserByID(ctx, "u2").Return(&entity.User{ID: "u2", IsActive: true}, nil)
+	userRepo.EXPECT().GetActiveUsersIDsByTeamName(ctx, "", []string{"u2"}).Return([]string{}, nil)
+	prRepo.EXPECT().GetReviewsByReviewerIDs(ctx, []string{"u2"}).Return([]*entity.ReviewRecord{}, nil)
+	userRepo.EXPECT().MarkAbsencesHandled(ctx, []int64{2}).Return(nil)
+
+	userRepo.EXPECT().
+		ClaimStartedAbsence(ctx, 3, []int64{1}).
+		Return(nil, entity.ErrNotFound).
+		Once()
+
+	handled, err := uc.ReassignReviewsOfAbsentUsers(ctx, 3)
+
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, 1, handled)
+	userRepo.AssertNotCalled(t, "MarkAbsencesHandled", mock.Anything, []int64{1})
+}
+
+func TestUseCase_UpdateUser_WorkHours(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	start, end := "10:00", ""
+	req := &entity.UpdateUserRequest{UserID: "u1", WorkHoursStart: &start, WorkHoursEnd: &end}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", Username: "user1", WorkHoursStart: "09:00", WorkHoursEnd: "17:00"}, nil)
+	userRepo.EXPECT().
+		UpdateProfile(ctx, &entity.User{ID: "u1", Username: "user1", WorkHoursStart: "10:00"}).
+		Return(nil)
+
+	user, err := uc.UpdateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10:00", user.WorkHoursStart)
+	assert.Empty(t, user.WorkHoursEnd)
+}
+
+func TestUseCase_UpdateUser_ZeroMaxOpenReviewsDropsCap(t *testing.T) {
+	uc, userRepo, _, _, _ := newUseCaseWithMocks(t)
+
+	ctx := context.Background()
+	current, zero := 3, 0
+	req := &entity.UpdateUserRequest{UserID: "u1", MaxOpenReviews: &zero}
+
+	userRepo.EXPECT().
+		GetUserByID(ctx, "u1").
+		Return(&entity.User{ID: "u1", Username: "user1", MaxOpenReviews: &current}, nil)
+	userRepo.EXPECT().
+		UpdateProfile(ctx, &entity.User{ID: "u1", Username: "user1"}).
+		Return(nil)
+
+	user, err := uc.UpdateUser(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Nil(t, user.MaxOpenReviews)
 }
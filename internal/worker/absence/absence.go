@@ -0,0 +1,55 @@
+package absence
+
+import (
+	"context"
+	"time"
+
+	"github.com/derletzte256/avito-assignment-2025-autumn/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type UseCase interface {
+	ReassignReviewsOfAbsentUsers(ctx context.Context, minDays int) (int, error)
+}
+
+// Worker periodically hands over open reviews of users whose long absence has
+// started, so they do not block PRs until the user is back.
+type Worker struct {
+	uc       UseCase
+	minDays  int
+	interval time.Duration
+}
+
+func NewWorker(uc UseCase, minDays int, interval time.Duration) *Worker {
+	return &Worker{uc: uc, minDays: minDays, interval: interval}
+}
+
+// Run checks for started absences right away and then every interval until
+// ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.runOnce(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) runOnce(ctx context.Context) {
+	l := logger.FromCtx(ctx)
+
+	// Absences are handled one by one, so some may be done even on error.
+	handled, err := w.uc.ReassignReviewsOfAbsentUsers(ctx, w.minDays)
+	if err != nil && ctx.Err() == nil {
+		l.Error("failed to reassign reviews of absent users", zap.Error(err))
+	}
+	if handled > 0 {
+		l.Info("reassigned reviews of absent users", zap.Int("absences", handled))
+	}
+}
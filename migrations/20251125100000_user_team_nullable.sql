@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users removed from a team keep their review and authoring history but no
+-- longer belong to any team.
+ALTER TABLE "user" ALTER COLUMN team_name DROP NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Deleting users without a team would cascade into their reviews and pull
+-- requests, so the rollback refuses to run until they are assigned to a team.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM "user" WHERE team_name IS NULL) THEN
+        RAISE EXCEPTION 'users without a team exist, assign them to a team before rolling back';
+    END IF;
+END $$;
+ALTER TABLE "user" ALTER COLUMN team_name SET NOT NULL;
+-- +goose StatementEnd
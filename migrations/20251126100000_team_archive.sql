@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team ADD COLUMN archived_at TIMESTAMPTZ;
+
+-- Teams are archived instead of deleted, so deleting a team that still has
+-- users must fail rather than wipe their PRs and reviews.
+ALTER TABLE "user"
+    DROP CONSTRAINT user_team_name_fkey,
+    ADD CONSTRAINT user_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES team(name) ON DELETE RESTRICT ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user"
+    DROP CONSTRAINT user_team_name_fkey,
+    ADD CONSTRAINT user_team_name_fkey
+        FOREIGN KEY (team_name) REFERENCES team(name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE team DROP COLUMN IF EXISTS archived_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team
+    ADD COLUMN parent_name TEXT REFERENCES team(name) ON DELETE RESTRICT ON UPDATE CASCADE,
+    ADD CONSTRAINT team_parent_name_not_self CHECK (parent_name <> name);
+
+CREATE INDEX idx_team_parent_name
+    ON team (parent_name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_team_parent_name;
+ALTER TABLE team
+    DROP CONSTRAINT IF EXISTS team_parent_name_not_self,
+    DROP COLUMN IF EXISTS parent_name;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A user belongs to at most one primary team, mirrored in "user".team_name to
+-- keep the single-team API working, and to any number of secondary teams.
+CREATE TABLE team_membership (
+    user_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    team_name TEXT NOT NULL REFERENCES team(name) ON DELETE RESTRICT ON UPDATE CASCADE,
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, team_name)
+);
+
+CREATE UNIQUE INDEX idx_team_membership_primary
+    ON team_membership (user_id) WHERE is_primary;
+
+CREATE INDEX idx_team_membership_team_name
+    ON team_membership (team_name);
+
+INSERT INTO team_membership (user_id, team_name, is_primary)
+SELECT id, team_name, TRUE
+FROM "user"
+WHERE team_name IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_membership;
+-- +goose StatementEnd
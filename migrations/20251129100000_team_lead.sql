@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE team_membership
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'member',
+    ADD CONSTRAINT team_membership_role_check CHECK (role IN ('member', 'lead'));
+
+-- Teams opt in to handing reviews nobody else can take over to their leads.
+ALTER TABLE team ADD COLUMN escalate_to_lead BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_team_membership_leads
+    ON team_membership (team_name) WHERE role = 'lead';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_team_membership_leads;
+ALTER TABLE team DROP COLUMN IF EXISTS escalate_to_lead;
+ALTER TABLE team_membership
+    DROP CONSTRAINT IF EXISTS team_membership_role_check,
+    DROP COLUMN IF EXISTS role;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE team_settings (
+    team_name TEXT PRIMARY KEY REFERENCES team(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    reviewer_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewer_count BETWEEN 0 AND 5),
+    selection_strategy TEXT NOT NULL DEFAULT 'random',
+    review_sla_hours INTEGER NOT NULL DEFAULT 0 CHECK (review_sla_hours >= 0),
+    approval_quorum INTEGER NOT NULL DEFAULT 1,
+    author_chooses_reviewers BOOLEAN NOT NULL DEFAULT FALSE,
+    cross_team_fallback BOOLEAN NOT NULL DEFAULT TRUE,
+    version INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT team_settings_quorum_check CHECK (approval_quorum BETWEEN 0 AND reviewer_count)
+);
+
+INSERT INTO team_settings (team_name)
+SELECT name
+FROM team;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS team_settings;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "user"
+    ADD COLUMN email TEXT,
+    ADD COLUMN chat_handle TEXT,
+    ADD COLUMN timezone TEXT,
+    ADD COLUMN seniority TEXT CHECK (seniority IN ('junior', 'middle', 'senior', 'staff', 'principal'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user"
+    DROP COLUMN IF EXISTS seniority,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS chat_handle,
+    DROP COLUMN IF EXISTS email;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Deleted users are renamed to a pseudonymous ID; ON UPDATE CASCADE carries it
+-- over to their PRs and reviews. original_id is dropped on erasure.
+ALTER TABLE "user"
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN original_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user"
+    DROP COLUMN IF EXISTS original_id,
+    DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
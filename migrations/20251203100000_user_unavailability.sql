@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- Set once open reviews of the user were handed over for a long absence.
+    reviews_reassigned_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_unavailability_range_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user_id_ends_at ON user_unavailability(user_id, ends_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_unavailability;
+-- +goose StatementEnd
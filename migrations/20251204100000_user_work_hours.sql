@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Local working hours in the user's timezone; NULL means the 09:00-18:00 default.
+ALTER TABLE "user"
+    ADD COLUMN work_hours_start TIME,
+    ADD COLUMN work_hours_end TIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "user"
+    DROP COLUMN IF EXISTS work_hours_end,
+    DROP COLUMN IF EXISTS work_hours_start;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- NULL on the user falls back to the team default, 0 in team settings means no cap.
+ALTER TABLE "user"
+    ADD COLUMN max_open_reviews INTEGER CHECK (max_open_reviews > 0);
+
+ALTER TABLE team_settings
+    ADD COLUMN max_open_reviews INTEGER NOT NULL DEFAULT 0 CHECK (max_open_reviews >= 0);
+
+-- Open reviews of every user next to the cap that applies to them; the cap is
+-- NULL for users without one.
+CREATE VIEW user_review_load AS
+SELECT u.id AS user_id,
+    (
+        SELECT COUNT(*)
+        FROM reviewer r
+        JOIN pull_request pr ON pr.id = r.pull_request_id
+        JOIN pull_request_status s ON s.id = pr.status_id
+        WHERE r.user_id = u.id AND s.name = 'OPEN'
+    ) AS open_reviews,
+    COALESCE(u.max_open_reviews, NULLIF(ts.max_open_reviews, 0)) AS max_open_reviews
+FROM "user" u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name;
+
+-- PRs that got fewer reviewers than the team needs because every remaining
+-- candidate was at their cap.
+CREATE TABLE review_queue (
+    pull_request_id TEXT PRIMARY KEY REFERENCES pull_request(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    missing_reviewers INTEGER NOT NULL CHECK (missing_reviewers > 0),
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_queue;
+DROP VIEW IF EXISTS user_review_load;
+ALTER TABLE team_settings DROP COLUMN IF EXISTS max_open_reviews;
+ALTER TABLE "user" DROP COLUMN IF EXISTS max_open_reviews;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reviewer changes made by bulk operations such as team rebalancing. User IDs
+-- and the team name follow pseudonymization and renames through ON UPDATE CASCADE.
+CREATE TABLE review_audit_event (
+    id BIGSERIAL PRIMARY KEY,
+    event TEXT NOT NULL,
+    team_name TEXT NOT NULL REFERENCES team(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    pull_request_id TEXT NOT NULL REFERENCES pull_request(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    old_reviewer_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    new_reviewer_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_review_audit_event_team_name ON review_audit_event(team_name, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_audit_event;
+-- +goose StatementEnd
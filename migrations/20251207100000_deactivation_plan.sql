@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Mass deactivation plans previewed with dry_run. The fingerprint covers the
+-- reviews the plan was built from, so a plan is only applied while they stay
+-- the same.
+CREATE TABLE deactivation_plan (
+    token TEXT PRIMARY KEY,
+    team_name TEXT NOT NULL REFERENCES team(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    user_ids TEXT[] NOT NULL,
+    fingerprint TEXT NOT NULL,
+    reassignments JSONB NOT NULL,
+    unreassigned JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS deactivation_plan;
+-- +goose StatementEnd
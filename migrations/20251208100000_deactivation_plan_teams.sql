@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Plans of mass deactivations across teams have no single team; teams holds
+-- their per-team breakdown.
+ALTER TABLE deactivation_plan
+    ALTER COLUMN team_name DROP NOT NULL,
+    ADD COLUMN teams JSONB NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM deactivation_plan WHERE team_name IS NULL;
+ALTER TABLE deactivation_plan
+    DROP COLUMN IF EXISTS teams,
+    ALTER COLUMN team_name SET NOT NULL;
+-- +goose StatementEnd
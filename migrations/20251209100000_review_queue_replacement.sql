@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Inactive reviewers whose reviews a queued PR waits to have taken over. A
+-- backlog reviewer replaces one of them; other queued slots only add a
+-- reviewer. Reviewer IDs follow pseudonymization through ON UPDATE CASCADE.
+CREATE TABLE review_queue_replacement (
+    pull_request_id TEXT NOT NULL REFERENCES review_queue(pull_request_id) ON DELETE CASCADE ON UPDATE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES "user"(id) ON DELETE CASCADE ON UPDATE CASCADE,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS review_queue_replacement;
+-- +goose StatementEnd